@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kube-zen/zen-sdk/pkg/zenlead"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,7 +46,8 @@ const (
 // LeaderGuard wraps a reconcile.Reconciler to prevent "Split Brain" scenarios.
 // Deprecated: This approach relies on pod annotations set by zen-lead, which is incompatible
 // with zen-lead's Day-0 "no pod mutation" contract. Use controller-runtime's built-in leader
-// election via zen-sdk/pkg/leader.ApplyRequiredLeaderElection() instead.
+// election via zen-sdk/pkg/leader.ApplyRequiredLeaderElection() instead, or
+// zen-sdk/pkg/zenlead.LeaseGuard where individual reconcilers must be gated on the Lease.
 //
 // This guard ensures only the leader pod processes reconciliation events,
 // while follower pods wait and requeue. This prevents duplicate work and
@@ -70,13 +72,8 @@ func NewLeaderGuard(client client.Client, logger logr.Logger) *LeaderGuard {
 		podName = os.Getenv("HOSTNAME")
 	}
 
-	podNamespace := os.Getenv("POD_NAMESPACE")
-	if podNamespace == "" {
-		// Try to read from service account namespace file
-		if data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err == nil {
-			podNamespace = string(data)
-		}
-	}
+	// Empty outside a pod
+	podNamespace, _ := zenlead.PodNamespace()
 
 	return &LeaderGuard{
 		client:       client,
@@ -87,7 +84,7 @@ func NewLeaderGuard(client client.Client, logger logr.Logger) *LeaderGuard {
 }
 
 // Wrap wraps a reconcile.Reconciler with leader guard logic.
-// Deprecated: Use controller-runtime's built-in leader election instead.
+// Deprecated: Use controller-runtime's built-in leader election, or zenlead.LeaseGuard.Wrap.
 // Only the leader pod processes reconciliation events; all
 // followers requeue and wait.
 func (lg *LeaderGuard) Wrap(inner reconcile.Reconciler) reconcile.Reconciler {
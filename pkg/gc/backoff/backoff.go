@@ -19,6 +19,7 @@ limitations under the License.
 package backoff
 
 import (
+	"math/rand"
 	"sync"
 	"time"
 
@@ -28,18 +29,56 @@ import (
 // Backoff implements exponential backoff for retry operations.
 // It is safe for concurrent use by multiple goroutines.
 type Backoff struct {
-	mu      sync.Mutex
-	backoff wait.Backoff
-	step    int
+	mu       sync.Mutex
+	backoff  wait.Backoff
+	strategy JitterStrategy
+	rand     func() float64
+	step     int
+	previous time.Duration // last returned duration, for JitterDecorrelated
 }
 
+// JitterStrategy selects how randomness is applied to backoff durations.
+type JitterStrategy string
+
+const (
+	// JitterProportional adds a random amount in [0, Jitter*duration) to the
+	// exponential duration (as k8s.io/apimachinery/pkg/util/wait does).
+	// This is the default.
+	JitterProportional JitterStrategy = ""
+
+	// JitterFull picks a random duration in (0, duration].
+	// Spreads retries the most; recommended for many clients retrying the same API.
+	JitterFull JitterStrategy = "full"
+
+	// JitterEqual keeps half the duration and randomizes the other half:
+	// duration/2 + random in [0, duration/2).
+	JitterEqual JitterStrategy = "equal"
+
+	// JitterDecorrelated picks a random duration between Duration and
+	// Factor times the previous duration (3x if Factor <= 1), capped at Cap.
+	// Steps grow from the previous random value rather than the step number.
+	JitterDecorrelated JitterStrategy = "decorrelated"
+
+	// JitterDeterministic adds a fixed Jitter/2 of the duration.
+	// Every caller gets the same durations; intended for tests only.
+	JitterDeterministic JitterStrategy = "deterministic"
+)
+
 // Config holds backoff configuration.
 type Config struct {
 	Steps    int           // Maximum number of retry steps
 	Duration time.Duration // Initial duration
 	Factor   float64       // Multiplier for each step
-	Jitter   float64       // Randomization factor (0.0 to 1.0)
+	Jitter   float64       // Randomization factor (0.0 to 1.0), used by JitterProportional and JitterDeterministic
 	Cap      time.Duration // Maximum duration cap
+
+	// JitterStrategy selects how randomness is applied (default: JitterProportional).
+	JitterStrategy JitterStrategy
+
+	// Rand returns a random number in [0.0, 1.0). Defaults to math/rand.Float64.
+	// Inject a fixed source to make jittered durations deterministic in tests.
+	// Must be safe for concurrent use if the Backoff is shared.
+	Rand func() float64
 }
 
 // DefaultConfig returns a default backoff configuration.
@@ -63,8 +102,18 @@ func NewBackoff(config Config) *Backoff {
 			Jitter:   config.Jitter,
 			Cap:      config.Cap,
 		},
-		step: 0,
+		strategy: config.JitterStrategy,
+		rand:     randOrDefault(config.Rand),
+		step:     0,
+	}
+}
+
+// randOrDefault returns r, or math/rand.Float64 if r is nil.
+func randOrDefault(r func() float64) func() float64 {
+	if r == nil {
+		return rand.Float64
 	}
+	return r
 }
 
 // Next returns the next backoff duration and increments the step counter.
@@ -78,28 +127,8 @@ func (b *Backoff) Next() time.Duration {
 		return 0
 	}
 
-	// Calculate duration for current step (0-indexed)
-	// Step 0 = Duration
-	// Step 1 = Duration * Factor
-	// Step 2 = Duration * Factor^2
-	// etc.
-	duration := b.backoff.Duration
-	for i := 0; i < b.step; i++ {
-		duration = time.Duration(float64(duration) * b.backoff.Factor)
-		if duration > b.backoff.Cap {
-			duration = b.backoff.Cap
-		}
-	}
-
-	// Apply jitter (simplified: deterministic for testing)
-	// In production, use proper randomization
-	if b.backoff.Jitter > 0 {
-		jitterAmount := time.Duration(float64(duration) * b.backoff.Jitter)
-		// For deterministic testing, use half jitter
-		// In real usage, this would be random between 0 and jitterAmount
-		duration += jitterAmount / 2
-	}
-
+	duration := b.jitter(b.exponential())
+	b.previous = duration
 	b.step++
 	return duration
 }
@@ -110,6 +139,66 @@ func (b *Backoff) Reset() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	b.step = 0
+	b.previous = 0
+}
+
+// exponential returns the un-jittered duration for the current step (0-indexed).
+// Step 0 = Duration
+// Step 1 = Duration * Factor
+// Step 2 = Duration * Factor^2
+// etc., capped at Cap.
+func (b *Backoff) exponential() time.Duration {
+	duration := b.backoff.Duration
+	for i := 0; i < b.step; i++ {
+		duration = time.Duration(float64(duration) * b.backoff.Factor)
+		if duration > b.backoff.Cap {
+			duration = b.backoff.Cap
+		}
+	}
+	return duration
+}
+
+// jitter applies the configured strategy to an exponential duration.
+func (b *Backoff) jitter(duration time.Duration) time.Duration {
+	switch b.strategy {
+	case JitterFull:
+		// (0, duration], so a jittered step is never mistaken for exhaustion
+		return duration - time.Duration(b.rand()*float64(duration))
+
+	case JitterEqual:
+		half := duration / 2
+		return duration - half + time.Duration(b.rand()*float64(half))
+
+	case JitterDecorrelated:
+		base := b.backoff.Duration
+		if b.previous == 0 {
+			return base
+		}
+		factor := b.backoff.Factor
+		if factor <= 1 {
+			factor = 3
+		}
+		upper := time.Duration(float64(b.previous) * factor)
+		if b.backoff.Cap > 0 && upper > b.backoff.Cap {
+			upper = b.backoff.Cap
+		}
+		if upper <= base {
+			return upper
+		}
+		return base + time.Duration(b.rand()*float64(upper-base))
+
+	case JitterDeterministic:
+		if b.backoff.Jitter > 0 {
+			duration += time.Duration(float64(duration)*b.backoff.Jitter) / 2
+		}
+		return duration
+
+	default:
+		if b.backoff.Jitter > 0 {
+			duration += time.Duration(b.rand() * b.backoff.Jitter * float64(duration))
+		}
+		return duration
+	}
 }
 
 // Step returns the current step number (0-indexed).
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+// fixedRand returns a random source that always yields v.
+func fixedRand(v float64) func() float64 {
+	return func() float64 { return v }
+}
+
+func jitterConfig(strategy JitterStrategy, r func() float64) Config {
+	return Config{
+		Steps:          4,
+		Duration:       100 * time.Millisecond,
+		Factor:         2.0,
+		Jitter:         0.5,
+		Cap:            time.Second,
+		JitterStrategy: strategy,
+		Rand:           r,
+	}
+}
+
+func TestBackoff_JitterStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy JitterStrategy
+		rand     float64
+		want     []time.Duration
+	}{
+		{
+			name:     "proportional",
+			strategy: JitterProportional,
+			rand:     0.5, // adds 0.5 * Jitter(0.5) = 25%
+			want:     []time.Duration{125 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond, time.Second},
+		},
+		{
+			name:     "full",
+			strategy: JitterFull,
+			rand:     0.75, // keeps 25%
+			want:     []time.Duration{25 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:     "equal",
+			strategy: JitterEqual,
+			rand:     0.5, // half + quarter
+			want:     []time.Duration{75 * time.Millisecond, 150 * time.Millisecond, 300 * time.Millisecond, 600 * time.Millisecond},
+		},
+		{
+			name:     "decorrelated",
+			strategy: JitterDecorrelated,
+			rand:     0.5, // base + (previous*2 - base)/2
+			want:     []time.Duration{100 * time.Millisecond, 150 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond},
+		},
+		{
+			name:     "deterministic",
+			strategy: JitterDeterministic,
+			rand:     0.9, // ignored
+			want:     []time.Duration{125 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond, time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBackoff(jitterConfig(tt.strategy, fixedRand(tt.rand)))
+			for i, want := range tt.want {
+				if got := b.Next(); got != want {
+					t.Errorf("step %d: Next() = %v, want %v", i, got, want)
+				}
+			}
+			if got := b.Next(); got != 0 {
+				t.Errorf("Next() after %d steps = %v, want 0", len(tt.want), got)
+			}
+		})
+	}
+}
+
+func TestBackoff_FullJitterNeverZero(t *testing.T) {
+	// rand() close to 1 must not yield 0, which Next uses to signal exhaustion
+	b := NewBackoff(jitterConfig(JitterFull, fixedRand(0.9999999999)))
+	if d := b.Next(); d <= 0 {
+		t.Errorf("Next() = %v, want > 0", d)
+	}
+}
+
+func TestBackoff_DecorrelatedRespectsCap(t *testing.T) {
+	config := jitterConfig(JitterDecorrelated, fixedRand(0.99))
+	config.Steps = 20
+	config.Cap = 300 * time.Millisecond
+	b := NewBackoff(config)
+
+	for d := b.Next(); d != 0; d = b.Next() {
+		if d > config.Cap || d < config.Duration {
+			t.Errorf("Next() = %v, want within [%v, %v]", d, config.Duration, config.Cap)
+		}
+	}
+
+	// Reset restarts the decorrelated sequence from Duration
+	b.Reset()
+	if d := b.Next(); d != config.Duration {
+		t.Errorf("Next() after Reset() = %v, want %v", d, config.Duration)
+	}
+}
+
+func TestBackoff_DefaultRandSpreadsReplicas(t *testing.T) {
+	// Independent backoffs with the default source should not all retry in lockstep
+	config := jitterConfig(JitterFull, nil)
+	first := NewBackoff(config).Next()
+	for i := 0; i < 20; i++ {
+		if NewBackoff(config).Next() != first {
+			return
+		}
+	}
+	t.Errorf("20 backoffs with full jitter all returned %v", first)
+}
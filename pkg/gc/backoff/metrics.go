@@ -0,0 +1,114 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"sync"
+
+	"github.com/kube-zen/zen-sdk/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetrics exports Registry backoff state labeled by registry name:
+// zen_backoff_keys (keys currently backing off, i.e. step > 0) and
+// zen_backoff_steps_total (backoff steps taken). Per-key series are opt-in,
+// see NewPerKeyPrometheusMetrics.
+type PrometheusMetrics struct {
+	name  string
+	keys  *prometheus.GaugeVec
+	total *prometheus.CounterVec
+	steps *prometheus.GaugeVec // nil unless per-key metrics are enabled
+
+	mu         sync.Mutex
+	backingOff map[string]struct{}
+}
+
+// NewPrometheusMetrics creates aggregate step metrics for the named registry and
+// registers them with the controller-runtime metrics registry.
+func NewPrometheusMetrics(name string) *PrometheusMetrics {
+	return &PrometheusMetrics{
+		name: name,
+		keys: metrics.Register(prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "zen_backoff_keys",
+				Help: "Number of keys currently backing off (step > 0)",
+			},
+			[]string{"registry"},
+		)),
+		total: metrics.Register(prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_backoff_steps_total",
+				Help: "Total number of backoff steps taken",
+			},
+			[]string{"registry"},
+		)),
+		backingOff: make(map[string]struct{}),
+	}
+}
+
+// NewPerKeyPrometheusMetrics is NewPrometheusMetrics that also exports the
+// zen_backoff_step gauge labeled by registry and key. Keys are removed from the
+// gauge when they are forgotten or evicted, but every backing-off key is its
+// own series, so only use it when keys are few and bounded (e.g. one per
+// configured target, not one per object UID).
+func NewPerKeyPrometheusMetrics(name string) *PrometheusMetrics {
+	m := NewPrometheusMetrics(name)
+	m.steps = metrics.Register(prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "zen_backoff_step",
+			Help: "Current backoff step per key (0 after a successful attempt)",
+		},
+		[]string{"registry", "key"},
+	))
+	return m
+}
+
+// SetStep implements StepMetrics.
+func (m *PrometheusMetrics) SetStep(key string, step int) {
+	if step > 0 {
+		m.total.WithLabelValues(m.name).Inc()
+	}
+	m.track(key, step > 0)
+	if m.steps != nil {
+		m.steps.WithLabelValues(m.name, key).Set(float64(step))
+	}
+}
+
+// DeleteKey implements StepMetrics.
+func (m *PrometheusMetrics) DeleteKey(key string) {
+	m.track(key, false)
+	if m.steps != nil {
+		m.steps.DeleteLabelValues(m.name, key)
+	}
+}
+
+// track updates zen_backoff_keys when key starts or stops backing off. The
+// gauge is adjusted rather than set, so registries sharing a name add up.
+func (m *PrometheusMetrics) track(key string, backingOff bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, tracked := m.backingOff[key]
+	switch {
+	case backingOff && !tracked:
+		m.backingOff[key] = struct{}{}
+		m.keys.WithLabelValues(m.name).Inc()
+	case !backingOff && tracked:
+		delete(m.backingOff, key)
+		m.keys.WithLabelValues(m.name).Dec()
+	}
+}
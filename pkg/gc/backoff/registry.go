@@ -0,0 +1,231 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/gc/internal/idle"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// DefaultIdleTimeout is how long a key may go unused before the Registry evicts it.
+const DefaultIdleTimeout = 10 * time.Minute
+
+// StepMetrics receives per-key step changes from a Registry.
+// Implementations must be safe for concurrent use.
+type StepMetrics interface {
+	// SetStep records the current step of a key (0 after Reset).
+	SetStep(key string, step int)
+
+	// DeleteKey removes a key that was forgotten or evicted.
+	DeleteKey(key string)
+}
+
+// RegistryConfig holds Registry configuration.
+type RegistryConfig struct {
+	// Backoff is the configuration used for every key.
+	Backoff Config
+
+	// IdleTimeout evicts keys not used for this long (default: DefaultIdleTimeout).
+	// Negative disables eviction.
+	IdleTimeout time.Duration
+
+	// Metrics optionally receives step changes (see NewPrometheusMetrics).
+	Metrics StepMetrics
+
+	// Now is the clock idle keys are measured against (default: time.Now).
+	Now func() time.Time
+}
+
+// Registry holds one Backoff per key (e.g., a resource UID or NamespacedName).
+// Keys are created on first use and evicted after IdleTimeout without use.
+// It is safe for concurrent use by multiple goroutines.
+type Registry struct {
+	mu      sync.Mutex
+	config  RegistryConfig
+	entries *idle.Map[*registryEntry]
+}
+
+// registryEntry is the per-key state of a Registry.
+type registryEntry struct {
+	backoff *Backoff
+	retryAt time.Time // zero until Next, and after Reset
+}
+
+// NewRegistry creates a new per-key backoff registry.
+func NewRegistry(config RegistryConfig) *Registry {
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = DefaultIdleTimeout
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	var onEvict func(string)
+	if config.Metrics != nil {
+		onEvict = config.Metrics.DeleteKey
+	}
+	return &Registry{
+		config:  config,
+		entries: idle.NewMap[*registryEntry](config.IdleTimeout, config.Now, onEvict),
+	}
+}
+
+// Next returns the next backoff duration for key and increments its step counter.
+// Returns 0 if the key has reached the maximum number of steps.
+func (r *Registry) Next(key string) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := r.entries.Get(key, func() *registryEntry {
+		return &registryEntry{backoff: NewBackoff(r.config.Backoff)}
+	})
+	duration := entry.backoff.Next()
+	entry.retryAt = r.config.Now().Add(duration)
+	if r.config.Metrics != nil {
+		r.config.Metrics.SetStep(key, entry.backoff.Step())
+	}
+	return duration
+}
+
+// Reset resets the backoff for key to its initial state, typically after a
+// successful attempt. The key stays registered until it is forgotten or idle.
+func (r *Registry) Reset(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries.Touch(key)
+	if !ok {
+		return
+	}
+	entry.backoff.Reset()
+	entry.retryAt = time.Time{}
+	if r.config.Metrics != nil {
+		r.config.Metrics.SetStep(key, 0)
+	}
+}
+
+// Forget removes key from the registry (e.g., when the resource was deleted).
+func (r *Registry) Forget(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries.Delete(key) && r.config.Metrics != nil {
+		r.config.Metrics.DeleteKey(key)
+	}
+}
+
+// Step returns the current step number (0-indexed) of key, 0 if unknown.
+func (r *Registry) Step(key string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.entries.Peek(key); ok {
+		return entry.backoff.Step()
+	}
+	return 0
+}
+
+// RetryAt returns when key may be retried: the time of its last Next call plus
+// the duration Next returned (so an exhausted key may be retried immediately).
+// Returns false if key is unknown or was Reset since.
+func (r *Registry) RetryAt(key string) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries.Peek(key)
+	if !ok || entry.retryAt.IsZero() {
+		return time.Time{}, false
+	}
+	return entry.retryAt, true
+}
+
+// IsExhausted returns true if key has reached the maximum number of steps.
+func (r *Registry) IsExhausted(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.entries.Peek(key); ok {
+		return entry.backoff.IsExhausted()
+	}
+	return r.config.Backoff.Steps <= 0
+}
+
+// Len returns the number of registered keys.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entries.Len()
+}
+
+// Keys returns the registered keys, in no particular order.
+func (r *Registry) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entries.Keys()
+}
+
+// EvictIdle removes keys not used within IdleTimeout and returns how many were removed.
+// Next also evicts idle keys periodically, so calling EvictIdle is optional.
+func (r *Registry) EvictIdle() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entries.EvictIdle()
+}
+
+// registryRateLimiter adapts a Registry to workqueue.TypedRateLimiter.
+type registryRateLimiter[T comparable] struct {
+	registry *Registry
+	keyFunc  func(T) string
+}
+
+// NewTypedRateLimiter adapts a Registry to a client-go/controller-runtime
+// workqueue.TypedRateLimiter, using keyFunc to derive the registry key of an item:
+//
+//	limiter := backoff.NewTypedRateLimiter(registry, func(req reconcile.Request) string {
+//		return req.String()
+//	})
+//	ctrl.NewControllerManagedBy(mgr).WithOptions(controller.Options{RateLimiter: limiter})
+//
+// Once a key is exhausted, When keeps returning the configured Cap (or
+// DefaultConfig's Cap if none is set) rather than 0, so failing items are not
+// requeued in a hot loop.
+func NewTypedRateLimiter[T comparable](registry *Registry, keyFunc func(T) string) workqueue.TypedRateLimiter[T] {
+	return &registryRateLimiter[T]{registry: registry, keyFunc: keyFunc}
+}
+
+// When returns how long the item should wait before being requeued.
+func (l *registryRateLimiter[T]) When(item T) time.Duration {
+	if d := l.registry.Next(l.keyFunc(item)); d > 0 {
+		return d
+	}
+	if backoffCap := l.registry.config.Backoff.Cap; backoffCap > 0 {
+		return backoffCap
+	}
+	return DefaultConfig().Cap
+}
+
+// Forget stops tracking the item.
+func (l *registryRateLimiter[T]) Forget(item T) {
+	l.registry.Forget(l.keyFunc(item))
+}
+
+// NumRequeues returns how many times the item has been requeued.
+func (l *registryRateLimiter[T]) NumRequeues(item T) int {
+	return l.registry.Step(l.keyFunc(item))
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fakeClock is a manually advanced clock for idle-eviction tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// recordingMetrics records the last step per key.
+type recordingMetrics struct {
+	mu    sync.Mutex
+	steps map[string]int
+}
+
+func (m *recordingMetrics) SetStep(key string, step int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.steps[key] = step
+}
+
+func (m *recordingMetrics) DeleteKey(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.steps, key)
+}
+
+func registryBackoffConfig() Config {
+	return Config{
+		Steps:          3,
+		Duration:       100 * time.Millisecond,
+		Factor:         2.0,
+		Cap:            time.Second,
+		JitterStrategy: JitterDeterministic,
+	}
+}
+
+func TestRegistry_PerKey(t *testing.T) {
+	r := NewRegistry(RegistryConfig{Backoff: registryBackoffConfig()})
+
+	if d := r.Next("a"); d != 100*time.Millisecond {
+		t.Errorf("Next(a) = %v, want 100ms", d)
+	}
+	if d := r.Next("a"); d != 200*time.Millisecond {
+		t.Errorf("Next(a) = %v, want 200ms", d)
+	}
+	// Keys are independent
+	if d := r.Next("b"); d != 100*time.Millisecond {
+		t.Errorf("Next(b) = %v, want 100ms", d)
+	}
+	if r.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", r.Len())
+	}
+
+	r.Next("a")
+	if !r.IsExhausted("a") || r.Next("a") != 0 {
+		t.Error("key a should be exhausted after 3 steps")
+	}
+
+	r.Reset("a")
+	if r.Step("a") != 0 || r.Len() != 2 {
+		t.Errorf("after Reset: Step(a) = %d, Len() = %d, want 0, 2", r.Step("a"), r.Len())
+	}
+
+	r.Forget("a")
+	if r.Len() != 1 || r.Step("a") != 0 {
+		t.Errorf("after Forget: Len() = %d, want 1", r.Len())
+	}
+}
+
+func TestRegistry_RetryAt(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	r := NewRegistry(RegistryConfig{Backoff: registryBackoffConfig(), Now: clock.Now})
+
+	if _, ok := r.RetryAt("a"); ok {
+		t.Error("RetryAt(a) ok before Next, want false")
+	}
+	r.Next("a")
+	clock.Advance(time.Second)
+	r.Next("a")
+	if at, ok := r.RetryAt("a"); !ok || !at.Equal(clock.Now().Add(200*time.Millisecond)) {
+		t.Errorf("RetryAt(a) = %v, %v, want 200ms after the last Next", at, ok)
+	}
+	r.Next("b")
+	if keys := r.Keys(); len(keys) != 2 {
+		t.Errorf("Keys() = %v, want a and b", keys)
+	}
+
+	r.Reset("a")
+	if _, ok := r.RetryAt("a"); ok {
+		t.Error("RetryAt(a) ok after Reset, want false")
+	}
+}
+
+func TestRegistry_IdleEviction(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	metrics := &recordingMetrics{steps: map[string]int{}}
+	r := NewRegistry(RegistryConfig{
+		Backoff:     registryBackoffConfig(),
+		IdleTimeout: time.Minute,
+		Metrics:     metrics,
+		Now:         clock.Now,
+	})
+
+	r.Next("idle")
+	clock.Advance(30 * time.Second)
+	r.Next("active")
+	if metrics.steps["idle"] != 1 || metrics.steps["active"] != 1 {
+		t.Errorf("metrics = %v, want step 1 for both keys", metrics.steps)
+	}
+
+	clock.Advance(40 * time.Second)
+	// The sweep runs on the next Next call after IdleTimeout
+	r.Next("active")
+	if r.Len() != 1 || r.Step("idle") != 0 {
+		t.Errorf("Len() = %d, want only the active key", r.Len())
+	}
+	if _, ok := metrics.steps["idle"]; ok {
+		t.Error("evicted key should be removed from metrics")
+	}
+
+	clock.Advance(2 * time.Minute)
+	if n := r.EvictIdle(); n != 1 || r.Len() != 0 {
+		t.Errorf("EvictIdle() = %d, Len() = %d, want 1, 0", n, r.Len())
+	}
+}
+
+func TestRegistry_EvictionDisabled(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	r := NewRegistry(RegistryConfig{Backoff: registryBackoffConfig(), IdleTimeout: -1, Now: clock.Now})
+
+	r.Next("a")
+	clock.Advance(24 * time.Hour)
+	if n := r.EvictIdle(); n != 0 || r.Len() != 1 {
+		t.Errorf("EvictIdle() = %d, Len() = %d, want 0, 1", n, r.Len())
+	}
+}
+
+func TestRegistry_ConcurrentAccess(t *testing.T) {
+	r := NewRegistry(RegistryConfig{Backoff: DefaultConfig()})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("key-%d", i%5)
+			for j := 0; j < 50; j++ {
+				r.Next(key)
+				if j%10 == 0 {
+					r.Reset(key)
+				}
+				r.Step(key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if r.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", r.Len())
+	}
+}
+
+func TestTypedRateLimiter(t *testing.T) {
+	r := NewRegistry(RegistryConfig{Backoff: registryBackoffConfig()})
+	limiter := NewTypedRateLimiter(r, func(req reconcile.Request) string { return req.String() })
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod"}}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := limiter.When(req); got != w {
+			t.Errorf("When() #%d = %v, want %v", i, got, w)
+		}
+	}
+	if n := limiter.NumRequeues(req); n != 3 {
+		t.Errorf("NumRequeues() = %d, want 3", n)
+	}
+
+	limiter.Forget(req)
+	if n := limiter.NumRequeues(req); n != 0 || r.Len() != 0 {
+		t.Errorf("after Forget: NumRequeues() = %d, Len() = %d, want 0, 0", n, r.Len())
+	}
+}
+
+func TestTypedRateLimiter_NoCap(t *testing.T) {
+	r := NewRegistry(RegistryConfig{Backoff: Config{Steps: 2, Duration: 100 * time.Millisecond, Factor: 2.0}})
+	limiter := NewTypedRateLimiter(r, func(key string) string { return key })
+
+	for i := 0; i < 5; i++ {
+		if got := limiter.When("item"); got <= 0 {
+			t.Fatalf("When() #%d = %v, want a positive delay without Cap", i, got)
+		}
+	}
+	if got := limiter.When("item"); got != DefaultConfig().Cap {
+		t.Errorf("When() after exhaustion = %v, want DefaultConfig().Cap", got)
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics("test-registry")
+	// A second registry with the same metric reuses the registered collector
+	other := NewPrometheusMetrics("other-registry")
+	if m.keys != other.keys || m.total != other.total {
+		t.Error("expected both registries to share the registered metrics")
+	}
+	if m.steps != nil {
+		t.Error("expected no per-key gauge by default")
+	}
+
+	m.SetStep("default/a", 1)
+	m.SetStep("default/a", 2)
+	m.SetStep("default/b", 1)
+	other.SetStep("default/a", 1)
+	if got := testutil.ToFloat64(m.keys.WithLabelValues("test-registry")); got != 2 {
+		t.Errorf("zen_backoff_keys = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.total.WithLabelValues("test-registry")); got != 3 {
+		t.Errorf("zen_backoff_steps_total = %v, want 3", got)
+	}
+
+	m.SetStep("default/a", 0)
+	m.DeleteKey("default/b")
+	m.DeleteKey("default/unknown")
+	if got := testutil.ToFloat64(m.keys.WithLabelValues("test-registry")); got != 0 {
+		t.Errorf("zen_backoff_keys after reset = %v, want 0", got)
+	}
+	if got := testutil.ToFloat64(other.keys.WithLabelValues("other-registry")); got != 1 {
+		t.Errorf("zen_backoff_keys of other registry = %v, want 1", got)
+	}
+}
+
+func TestPerKeyPrometheusMetrics(t *testing.T) {
+	m := NewPerKeyPrometheusMetrics("per-key-registry")
+
+	m.SetStep("default/pod", 3)
+	if got := testutil.ToFloat64(m.steps.WithLabelValues("per-key-registry", "default/pod")); got != 3 {
+		t.Errorf("zen_backoff_step = %v, want 3", got)
+	}
+
+	m.DeleteKey("default/pod")
+	if n := testutil.CollectAndCount(m.steps); n != 0 {
+		t.Errorf("series after DeleteKey = %d, want 0", n)
+	}
+}
@@ -0,0 +1,444 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gc provides a reusable garbage collection engine built from the
+// gc/ttl, gc/selector, gc/backoff and gc/ratelimiter primitives.
+//
+// A Collector periodically lists one resource type, selects expired objects
+// and deletes them under a rate limit, with per-resource backoff on failures:
+//
+//	collector, err := gc.NewCollector(dynamicClient, gc.Config{
+//		Name: "completed-jobs",
+//		GVR:  schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"},
+//		TTL:  &ttl.Spec{SecondsAfterCreation: &oneDay},
+//	})
+//	go collector.Run(ctx)
+package gc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/events"
+	"github.com/kube-zen/zen-sdk/pkg/gc/backoff"
+	"github.com/kube-zen/zen-sdk/pkg/gc/ratelimiter"
+	"github.com/kube-zen/zen-sdk/pkg/gc/selector"
+	"github.com/kube-zen/zen-sdk/pkg/gc/ttl"
+	"github.com/kube-zen/zen-sdk/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// Collector defaults.
+const (
+	DefaultInterval  = time.Minute
+	DefaultChunkSize = int64(500)
+)
+
+// Event reasons recorded by the Collector.
+const (
+	ReasonDeleted      = "GarbageCollected"
+	ReasonDeleteFailed = "GarbageCollectionFailed"
+)
+
+var (
+	// ErrInvalidConfig indicates a Collector configuration is invalid.
+	ErrInvalidConfig = errors.New("invalid collector configuration")
+)
+
+// Limiter paces delete calls. *ratelimiter.RateLimiter and
+// *ratelimiter.AdaptiveRateLimiter implement it. If the limiter also has an
+// Observe(time.Duration, error) method, every delete result is fed back to it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// observer is implemented by limiters that adapt to API server feedback.
+type observer interface {
+	Observe(latency time.Duration, err error)
+}
+
+// Config holds Collector configuration.
+type Config struct {
+	// Name identifies the collector in logs, events and metrics (required).
+	Name string
+
+	// GVR is the resource type to collect (required).
+	GVR schema.GroupVersionResource
+
+	// Namespace restricts collection to one namespace. Empty means all namespaces
+	// (or cluster-scoped resources).
+	Namespace string
+
+	// LabelSelector is applied server-side when listing.
+	LabelSelector *metav1.LabelSelector
+
+	// Conditions are evaluated client-side; only matching resources are collected.
+	Conditions *selector.Conditions
+
+	// TTL decides when a resource expires (required).
+	TTL *ttl.Spec
+
+	// PropagationPolicy for deletes (default: Background).
+	PropagationPolicy *metav1.DeletionPropagation
+
+	// DryRun evaluates and reports deletions without calling the API server.
+	DryRun bool
+
+	// MaxDeletionsPerSweep caps deletions per sweep; the rest wait for the
+	// next sweep. Zero means no limit.
+	MaxDeletionsPerSweep int
+
+	// ChunkSize is the page size for list calls (default: DefaultChunkSize).
+	ChunkSize int64
+
+	// Interval between sweeps in Run (default: DefaultInterval).
+	Interval time.Duration
+
+	// RateLimiter paces delete calls (default: ratelimiter.NewRateLimiter(ratelimiter.DefaultMaxPerSecond)).
+	RateLimiter Limiter
+
+	// Backoff delays retries of resources whose delete failed, keyed by UID
+	// (default: backoff.NewRegistry with backoff.DefaultConfig and Now). Once a
+	// resource's backoff is exhausted it is retried every Interval. Keys of
+	// resources no longer listed are forgotten after each sweep, so do not share
+	// the registry with other collectors.
+	Backoff *backoff.Registry
+
+	// EventRecorder records GarbageCollected/GarbageCollectionFailed events on
+	// the collected resources. Optional.
+	EventRecorder *events.Recorder
+
+	// Metrics receives sweep results. Optional; see NewPrometheusMetrics.
+	Metrics Metrics
+
+	// Now is the clock TTLs and retry times are evaluated against (default:
+	// time.Now). A custom Backoff registry should use the same clock.
+	Now func() time.Time
+}
+
+// SweepResult summarizes one sweep.
+type SweepResult struct {
+	// Listed is the number of resources returned by the API server.
+	Listed int
+	// Matched is the number of resources matching Conditions.
+	Matched int
+	// Expired is the number of matched resources whose TTL has passed.
+	Expired int
+	// Deleted is the number of resources deleted (or that would be, in dry-run).
+	Deleted int
+	// Failed is the number of failed delete calls.
+	Failed int
+	// BackingOff is the number of expired resources skipped because an earlier delete failed.
+	BackingOff int
+	// Deferred is the number of expired resources left for the next sweep by MaxDeletionsPerSweep.
+	Deferred int
+	// DryRun reports whether deletions were simulated.
+	DryRun bool
+	// Duration is how long the sweep took.
+	Duration time.Duration
+	// Errors holds delete errors, one per failed resource.
+	Errors []error
+}
+
+// Collector deletes expired resources of one type.
+// It is safe for concurrent use, but sweeps are serialized.
+type Collector struct {
+	client dynamic.Interface
+	config Config
+	logger *logging.Logger
+
+	sweepMu sync.Mutex
+}
+
+// NewCollector creates a Collector. Returns an error wrapping ErrInvalidConfig
+// if required fields are missing.
+func NewCollector(client dynamic.Interface, config Config) (*Collector, error) {
+	if client == nil {
+		return nil, fmt.Errorf("%w: dynamic client is required", ErrInvalidConfig)
+	}
+	if config.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidConfig)
+	}
+	if config.GVR.Resource == "" {
+		return nil, fmt.Errorf("%w: GVR resource is required", ErrInvalidConfig)
+	}
+	if config.TTL == nil {
+		return nil, fmt.Errorf("%w: TTL spec is required", ErrInvalidConfig)
+	}
+	if config.MaxDeletionsPerSweep < 0 {
+		return nil, fmt.Errorf("%w: max deletions per sweep must not be negative", ErrInvalidConfig)
+	}
+	if config.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(config.LabelSelector); err != nil {
+			return nil, fmt.Errorf("%w: label selector: %v", ErrInvalidConfig, err)
+		}
+	}
+
+	if config.PropagationPolicy == nil {
+		policy := metav1.DeletePropagationBackground
+		config.PropagationPolicy = &policy
+	}
+	if config.ChunkSize <= 0 {
+		config.ChunkSize = DefaultChunkSize
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.RateLimiter == nil {
+		config.RateLimiter = ratelimiter.NewRateLimiter(ratelimiter.DefaultMaxPerSecond)
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	if config.Backoff == nil {
+		config.Backoff = backoff.NewRegistry(backoff.RegistryConfig{
+			Backoff: backoff.DefaultConfig(),
+			Now:     config.Now,
+		})
+	}
+
+	return &Collector{
+		client: client,
+		config: config,
+		logger: logging.NewLogger("gc-collector").WithField("collector", config.Name),
+	}, nil
+}
+
+// Run sweeps immediately and then every Interval until ctx is cancelled.
+// Sweep errors are logged and do not stop the loop.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Sweep(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Error(err, "GC sweep failed",
+				logging.Operation("gc_sweep"),
+				logging.ResourceType(c.config.GVR.String()),
+				logging.ErrorCode("GC_SWEEP_ERROR"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep lists the configured resources page by page, and deletes those that
+// match Conditions and have expired. Delete failures are counted in the result
+// and retried in later sweeps after a per-resource backoff; only list errors
+// and context cancellation are returned as errors.
+func (c *Collector) Sweep(ctx context.Context) (*SweepResult, error) {
+	c.sweepMu.Lock()
+	defer c.sweepMu.Unlock()
+
+	start := c.config.Now()
+	result := &SweepResult{DryRun: c.config.DryRun}
+	seen := make(map[types.UID]bool)
+	err := c.list(ctx, func(resource *unstructured.Unstructured) error {
+		seen[resource.GetUID()] = true
+		return c.process(ctx, resource, result)
+	})
+	if err == nil {
+		c.pruneBackoff(seen)
+	}
+	result.Duration = c.config.Now().Sub(start)
+
+	if c.config.Metrics != nil {
+		c.config.Metrics.ObserveSweep(c.config.Name, c.config.GVR, result, err)
+	}
+	c.logger.Info("GC sweep completed",
+		logging.Operation("gc_sweep"),
+		logging.ResourceType(c.config.GVR.String()),
+		logging.Int("listed", result.Listed),
+		logging.Int("expired", result.Expired),
+		logging.Int("deleted", result.Deleted),
+		logging.Int("failed", result.Failed),
+		logging.Int("deferred", result.Deferred),
+		logging.Bool("dry_run", result.DryRun),
+		logging.Duration("duration", result.Duration))
+	return result, err
+}
+
+// list pages through the configured resources, calling visit for each one.
+// Stops at the first error returned by visit.
+func (c *Collector) list(ctx context.Context, visit func(*unstructured.Unstructured) error) error {
+	opts := metav1.ListOptions{Limit: c.config.ChunkSize}
+	if c.config.LabelSelector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(c.config.LabelSelector)
+		if err != nil {
+			return fmt.Errorf("label selector: %w", err)
+		}
+		opts.LabelSelector = sel.String()
+	}
+
+	for {
+		list, err := c.resourceClient().List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", c.config.GVR.String(), err)
+		}
+		for i := range list.Items {
+			if err := visit(&list.Items[i]); err != nil {
+				return err
+			}
+		}
+		if list.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
+
+// resourceClient returns the dynamic client for the configured GVR and namespace.
+func (c *Collector) resourceClient() dynamic.ResourceInterface {
+	resource := c.client.Resource(c.config.GVR)
+	if c.config.Namespace != "" {
+		return resource.Namespace(c.config.Namespace)
+	}
+	return resource
+}
+
+// evaluate decides whether a resource is collectible at now. The label
+// selector is applied server-side when listing, so it is not re-checked here.
+func (c *Collector) evaluate(resource *unstructured.Unstructured, now time.Time) decision {
+	return evaluate(resource, c.config.Conditions, nil, c.config.TTL, now)
+}
+
+// process evaluates one listed resource and deletes it if it has expired.
+// Only context cancellation is returned as an error.
+func (c *Collector) process(ctx context.Context, resource *unstructured.Unstructured, result *SweepResult) error {
+	result.Listed++
+	now := c.config.Now()
+	d := c.evaluate(resource, now)
+	if !d.matched {
+		return nil
+	}
+	result.Matched++
+	if !d.expired {
+		return nil
+	}
+	result.Expired++
+
+	if c.backingOff(resource.GetUID(), now) {
+		result.BackingOff++
+		return nil
+	}
+	if c.config.MaxDeletionsPerSweep > 0 && result.Deleted+result.Failed >= c.config.MaxDeletionsPerSweep {
+		result.Deferred++
+		return nil
+	}
+
+	if err := c.delete(ctx, resource); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		result.Failed++
+		result.Errors = append(result.Errors, err)
+		return nil
+	}
+	result.Deleted++
+	return nil
+}
+
+// delete deletes one resource under the rate limiter, recording events and backoff.
+// NotFound and UID-precondition conflicts mean the resource is already gone
+// (or was replaced) and count as success.
+func (c *Collector) delete(ctx context.Context, resource *unstructured.Unstructured) error {
+	if c.config.DryRun {
+		c.logger.Info("Would delete expired resource (dry-run)",
+			logging.Operation("gc_delete"),
+			logging.ResourceType(c.config.GVR.String()),
+			logging.Namespace(resource.GetNamespace()),
+			logging.Name(resource.GetName()))
+		return nil
+	}
+
+	if err := c.config.RateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	uid := resource.GetUID()
+	opts := metav1.DeleteOptions{PropagationPolicy: c.config.PropagationPolicy}
+	if uid != "" {
+		opts.Preconditions = &metav1.Preconditions{UID: &uid}
+	}
+
+	start := time.Now()
+	err := c.client.Resource(c.config.GVR).Namespace(resource.GetNamespace()).Delete(ctx, resource.GetName(), opts)
+	if o, ok := c.config.RateLimiter.(observer); ok {
+		o.Observe(time.Since(start), err)
+	}
+	if err != nil && (apierrors.IsNotFound(err) || apierrors.IsConflict(err)) {
+		err = nil
+	}
+
+	if err != nil {
+		retryAt := c.recordFailure(uid)
+		c.logger.Error(err, "Failed to delete expired resource",
+			logging.Operation("gc_delete"),
+			logging.ResourceType(c.config.GVR.String()),
+			logging.Namespace(resource.GetNamespace()),
+			logging.Name(resource.GetName()),
+			logging.String("retry_at", retryAt.Format(time.RFC3339)),
+			logging.ErrorCode("GC_DELETE_ERROR"))
+		c.config.EventRecorder.Eventf(resource, corev1.EventTypeWarning, ReasonDeleteFailed,
+			"%s failed to delete expired resource: %v", c.config.Name, err)
+		return fmt.Errorf("failed to delete %s %s/%s: %w", c.config.GVR.Resource, resource.GetNamespace(), resource.GetName(), err)
+	}
+
+	c.recordSuccess(uid)
+	c.config.EventRecorder.Eventf(resource, corev1.EventTypeNormal, ReasonDeleted,
+		"Deleted by %s: TTL expired", c.config.Name)
+	return nil
+}
+
+// backingOff reports whether a previous delete of uid failed and its retry time has not come.
+func (c *Collector) backingOff(uid types.UID, now time.Time) bool {
+	retryAt, ok := c.config.Backoff.RetryAt(string(uid))
+	return ok && now.Before(retryAt)
+}
+
+// recordFailure advances the backoff of uid and returns its next retry time.
+func (c *Collector) recordFailure(uid types.UID) time.Time {
+	c.config.Backoff.Next(string(uid))
+	retryAt, _ := c.config.Backoff.RetryAt(string(uid))
+	return retryAt
+}
+
+// recordSuccess clears the backoff state of uid.
+func (c *Collector) recordSuccess(uid types.UID) {
+	c.config.Backoff.Forget(string(uid))
+}
+
+// pruneBackoff drops backoff state of resources that no longer exist.
+func (c *Collector) pruneBackoff(seen map[types.UID]bool) {
+	for _, key := range c.config.Backoff.Keys() {
+		if !seen[types.UID(key)] {
+			c.config.Backoff.Forget(key)
+		}
+	}
+}
@@ -0,0 +1,403 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/gc/backoff"
+	"github.com/kube-zen/zen-sdk/pkg/gc/ratelimiter"
+	"github.com/kube-zen/zen-sdk/pkg/gc/selector"
+	"github.com/kube-zen/zen-sdk/pkg/gc/ttl"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+// testNow is the fixed "current" time of collector tests.
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newPod(name string, age time.Duration, labels map[string]string, phase string) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	pod.SetNamespace("default")
+	pod.SetName(name)
+	pod.SetUID(types.UID("uid-" + name))
+	pod.SetLabels(labels)
+	pod.SetCreationTimestamp(metav1.NewTime(testNow.Add(-age)))
+	if phase != "" {
+		_ = unstructured.SetNestedField(pod.Object, phase, "status", "phase")
+	}
+	return pod
+}
+
+func newFakeClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{podsGVR: "PodList"}, objects...)
+}
+
+func oneHourTTL() *ttl.Spec {
+	seconds := int64(3600)
+	return &ttl.Spec{SecondsAfterCreation: &seconds}
+}
+
+// deletedNames returns the names of resources deleted through the fake client.
+func deletedNames(client *dynamicfake.FakeDynamicClient) []string {
+	var names []string
+	for _, action := range client.Actions() {
+		if del, ok := action.(k8stesting.DeleteAction); ok {
+			names = append(names, del.GetName())
+		}
+	}
+	return names
+}
+
+func TestCollector_Sweep(t *testing.T) {
+	client := newFakeClient(
+		newPod("old-succeeded", 2*time.Hour, map[string]string{"app": "batch"}, "Succeeded"),
+		newPod("old-running", 2*time.Hour, map[string]string{"app": "batch"}, "Running"),
+		newPod("young-succeeded", 10*time.Minute, map[string]string{"app": "batch"}, "Succeeded"),
+		newPod("old-other-app", 2*time.Hour, map[string]string{"app": "web"}, "Succeeded"),
+	)
+	c, err := NewCollector(client, Config{
+		Name:          "test",
+		GVR:           podsGVR,
+		Namespace:     "default",
+		TTL:           oneHourTTL(),
+		RateLimiter:   ratelimiter.NewRateLimiter(1000),
+		Now:           func() time.Time { return testNow },
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "batch"}},
+		Conditions:    &selector.Conditions{Phase: []string{"Succeeded"}},
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	result, err := c.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep() unexpected error: %v", err)
+	}
+	if result.Listed != 3 || result.Matched != 2 || result.Expired != 1 || result.Deleted != 1 {
+		t.Errorf("Sweep() = %+v, want listed 3, matched 2, expired 1, deleted 1", result)
+	}
+
+	names := deletedNames(client)
+	if len(names) != 1 || names[0] != "old-succeeded" {
+		t.Errorf("deleted = %v, want [old-succeeded]", names)
+	}
+	if _, err := client.Resource(podsGVR).Namespace("default").Get(context.Background(), "old-succeeded", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("old-succeeded still exists: %v", err)
+	}
+}
+
+func TestCollector_DeleteOptions(t *testing.T) {
+	client := newFakeClient(newPod("expired", 2*time.Hour, nil, ""))
+	orphan := metav1.DeletePropagationOrphan
+	c, err := NewCollector(client, Config{
+		Name:              "test",
+		GVR:               podsGVR,
+		Namespace:         "default",
+		TTL:               oneHourTTL(),
+		RateLimiter:       ratelimiter.NewRateLimiter(1000),
+		Now:               func() time.Time { return testNow },
+		PropagationPolicy: &orphan,
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	if _, err := c.Sweep(context.Background()); err != nil {
+		t.Fatalf("Sweep() unexpected error: %v", err)
+	}
+	for _, action := range client.Actions() {
+		del, ok := action.(k8stesting.DeleteActionImpl)
+		if !ok {
+			continue
+		}
+		opts := del.GetDeleteOptions()
+		if opts.PropagationPolicy == nil || *opts.PropagationPolicy != orphan {
+			t.Errorf("PropagationPolicy = %v, want Orphan", opts.PropagationPolicy)
+		}
+		if opts.Preconditions == nil || opts.Preconditions.UID == nil || *opts.Preconditions.UID != "uid-expired" {
+			t.Errorf("Preconditions = %+v, want UID uid-expired", opts.Preconditions)
+		}
+		return
+	}
+	t.Fatal("no delete action recorded")
+}
+
+func TestCollector_DryRun(t *testing.T) {
+	client := newFakeClient(newPod("expired", 2*time.Hour, nil, ""))
+	c, err := NewCollector(client, Config{
+		Name:        "test",
+		GVR:         podsGVR,
+		Namespace:   "default",
+		TTL:         oneHourTTL(),
+		RateLimiter: ratelimiter.NewRateLimiter(1000),
+		Now:         func() time.Time { return testNow },
+		DryRun:      true,
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	result, err := c.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep() unexpected error: %v", err)
+	}
+	if !result.DryRun || result.Deleted != 1 {
+		t.Errorf("Sweep() = %+v, want dry-run with 1 deletion", result)
+	}
+	if names := deletedNames(client); len(names) != 0 {
+		t.Errorf("dry-run deleted %v, want nothing", names)
+	}
+}
+
+func TestCollector_MaxDeletionsPerSweep(t *testing.T) {
+	client := newFakeClient(
+		newPod("a", 2*time.Hour, nil, ""),
+		newPod("b", 2*time.Hour, nil, ""),
+		newPod("c", 2*time.Hour, nil, ""),
+	)
+	c, err := NewCollector(client, Config{
+		Name:                 "test",
+		GVR:                  podsGVR,
+		Namespace:            "default",
+		TTL:                  oneHourTTL(),
+		RateLimiter:          ratelimiter.NewRateLimiter(1000),
+		Now:                  func() time.Time { return testNow },
+		MaxDeletionsPerSweep: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	result, err := c.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep() unexpected error: %v", err)
+	}
+	if result.Deleted != 2 || result.Deferred != 1 {
+		t.Errorf("first Sweep() = %+v, want 2 deleted, 1 deferred", result)
+	}
+
+	result, err = c.Sweep(context.Background())
+	if err != nil {
+		t.Fatalf("Sweep() unexpected error: %v", err)
+	}
+	if result.Deleted != 1 || result.Deferred != 0 {
+		t.Errorf("second Sweep() = %+v, want 1 deleted", result)
+	}
+}
+
+func TestCollector_BackoffOnFailure(t *testing.T) {
+	client := newFakeClient(newPod("stuck", 2*time.Hour, nil, ""), newPod("fine", 2*time.Hour, nil, ""))
+	client.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.DeleteAction).GetName() == "stuck" {
+			return true, nil, apierrors.NewInternalError(errors.New("webhook unavailable"))
+		}
+		return false, nil, nil
+	})
+
+	now := testNow
+	var mu sync.Mutex
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	c, err := NewCollector(client, Config{
+		Name:        "test",
+		GVR:         podsGVR,
+		Namespace:   "default",
+		TTL:         oneHourTTL(),
+		RateLimiter: ratelimiter.NewRateLimiter(1000),
+		Now:         clock,
+		Backoff: backoff.NewRegistry(backoff.RegistryConfig{
+			Backoff: backoff.Config{Steps: 3, Duration: time.Minute, Factor: 2, Cap: time.Hour, JitterStrategy: backoff.JitterDeterministic},
+			Now:     clock,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	result, _ := c.Sweep(context.Background())
+	if result.Deleted != 1 || result.Failed != 1 || len(result.Errors) != 1 {
+		t.Fatalf("first Sweep() = %+v, want 1 deleted, 1 failed", result)
+	}
+
+	// Within the backoff window the failed resource is skipped
+	result, _ = c.Sweep(context.Background())
+	if result.BackingOff != 1 || result.Failed != 0 {
+		t.Errorf("second Sweep() = %+v, want 1 backing off", result)
+	}
+
+	mu.Lock()
+	now = now.Add(2 * time.Minute)
+	mu.Unlock()
+	result, _ = c.Sweep(context.Background())
+	if result.Failed != 1 {
+		t.Errorf("third Sweep() = %+v, want retry after backoff", result)
+	}
+	if c.config.Backoff.Step("uid-stuck") != 2 {
+		t.Errorf("backoff step = %d, want 2", c.config.Backoff.Step("uid-stuck"))
+	}
+}
+
+func TestCollector_NotFoundIsSuccess(t *testing.T) {
+	client := newFakeClient(newPod("gone", 2*time.Hour, nil, ""))
+	client.PrependReactor("delete", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "gone")
+	})
+	c, err := NewCollector(client, Config{
+		Name:        "test",
+		GVR:         podsGVR,
+		Namespace:   "default",
+		TTL:         oneHourTTL(),
+		RateLimiter: ratelimiter.NewRateLimiter(1000),
+		Now:         func() time.Time { return testNow },
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	result, err := c.Sweep(context.Background())
+	if err != nil || result.Deleted != 1 || result.Failed != 0 {
+		t.Errorf("Sweep() = %+v, %v, want NotFound counted as deleted", result, err)
+	}
+}
+
+func TestCollector_ListError(t *testing.T) {
+	client := newFakeClient()
+	client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewServiceUnavailable("etcd down")
+	})
+	c, err := NewCollector(client, Config{
+		Name:        "test",
+		GVR:         podsGVR,
+		Namespace:   "default",
+		TTL:         oneHourTTL(),
+		RateLimiter: ratelimiter.NewRateLimiter(1000),
+		Now:         func() time.Time { return testNow },
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	if _, err := c.Sweep(context.Background()); err == nil {
+		t.Error("Sweep() expected error when list fails")
+	}
+}
+
+func TestCollector_Metrics(t *testing.T) {
+	client := newFakeClient(newPod("expired", 2*time.Hour, nil, ""), newPod("young", time.Minute, nil, ""))
+	metrics := NewPrometheusMetrics()
+	c, err := NewCollector(client, Config{
+		Name:        "metrics-test",
+		GVR:         podsGVR,
+		Namespace:   "default",
+		TTL:         oneHourTTL(),
+		RateLimiter: ratelimiter.NewRateLimiter(1000),
+		Now:         func() time.Time { return testNow },
+		Metrics:     metrics,
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	if _, err := c.Sweep(context.Background()); err != nil {
+		t.Fatalf("Sweep() unexpected error: %v", err)
+	}
+	if got := testutil.ToFloat64(metrics.deleted.WithLabelValues("metrics-test", "pods", "false")); got != 1 {
+		t.Errorf("zen_gc_resources_deleted_total = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.sweeps.WithLabelValues("metrics-test", "pods", "success")); got != 1 {
+		t.Errorf("zen_gc_sweeps_total = %v, want 1", got)
+	}
+	// A second instance shares the registered collectors
+	if NewPrometheusMetrics().deleted != metrics.deleted {
+		t.Error("expected PrometheusMetrics instances to share registered metrics")
+	}
+}
+
+func TestNewCollector_InvalidConfig(t *testing.T) {
+	client := newFakeClient()
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "missing name", config: Config{GVR: podsGVR, TTL: oneHourTTL()}},
+		{name: "missing GVR", config: Config{Name: "x", TTL: oneHourTTL()}},
+		{name: "missing TTL", config: Config{Name: "x", GVR: podsGVR}},
+		{name: "negative max deletions", config: Config{Name: "x", GVR: podsGVR, TTL: oneHourTTL(), MaxDeletionsPerSweep: -1}},
+		{name: "invalid label selector", config: Config{Name: "x", GVR: podsGVR, TTL: oneHourTTL(),
+			LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: "Bogus"}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCollector(client, tt.config); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("NewCollector() error = %v, want ErrInvalidConfig", err)
+			}
+		})
+	}
+}
+
+func TestCollector_Run(t *testing.T) {
+	client := newFakeClient(newPod("expired", 2*time.Hour, nil, ""))
+	c, err := NewCollector(client, Config{
+		Name:        "test",
+		GVR:         podsGVR,
+		Namespace:   "default",
+		TTL:         oneHourTTL(),
+		RateLimiter: ratelimiter.NewRateLimiter(1000),
+		Now:         func() time.Time { return testNow },
+		Interval:    10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not return after context cancellation")
+	}
+	if names := deletedNames(client); len(names) != 1 {
+		t.Errorf("deleted = %v, want [expired]", names)
+	}
+}
@@ -16,62 +16,64 @@ limitations under the License.
 
 // Package fieldpath provides field path evaluation primitives for Kubernetes resources.
 // Extracted from zen-gc to enable reuse across components.
+//
+// The package-level getters are strict: the stored value must already have the
+// requested type. For lenient coercion, compile the path and call Lenient:
+//
+//	ttl, found, err := fieldpath.MustCompile("metadata.annotations.ttlSeconds").Lenient().GetInt64(resource)
 package fieldpath
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-// GetString retrieves a string value from a resource using a dot-separated field path.
+// GetString retrieves a string value from a resource using a field path.
 // Example: GetString(resource, "spec.severity") -> ("critical", true, nil)
 func GetString(resource *unstructured.Unstructured, path string) (string, bool, error) {
-	if path == "" {
-		return "", false, fmt.Errorf("field path cannot be empty")
+	p, err := Compile(path)
+	if err != nil {
+		return "", false, err
 	}
-
-	fieldPath := Parse(path)
-	return unstructured.NestedString(resource.Object, fieldPath...)
+	return p.GetString(resource)
 }
 
-// GetInt64 retrieves an int64 value from a resource using a dot-separated field path.
+// GetInt64 retrieves an int64 value from a resource using a field path.
 // Example: GetInt64(resource, "spec.ttlSeconds") -> (3600, true, nil)
 func GetInt64(resource *unstructured.Unstructured, path string) (int64, bool, error) {
-	if path == "" {
-		return 0, false, fmt.Errorf("field path cannot be empty")
+	p, err := Compile(path)
+	if err != nil {
+		return 0, false, err
 	}
-
-	fieldPath := Parse(path)
-	return unstructured.NestedInt64(resource.Object, fieldPath...)
+	return p.GetInt64(resource)
 }
 
-// GetBool retrieves a boolean value from a resource using a dot-separated field path.
+// GetBool retrieves a boolean value from a resource using a field path.
 // Example: GetBool(resource, "spec.enabled") -> (true, true, nil)
 func GetBool(resource *unstructured.Unstructured, path string) (bool, bool, error) {
-	if path == "" {
-		return false, false, fmt.Errorf("field path cannot be empty")
+	p, err := Compile(path)
+	if err != nil {
+		return false, false, err
 	}
-
-	fieldPath := Parse(path)
-	return unstructured.NestedBool(resource.Object, fieldPath...)
+	return p.GetBool(resource)
 }
 
-// GetFloat64 retrieves a float64 value from a resource using a dot-separated field path.
+// GetFloat64 retrieves a float64 value from a resource using a field path.
 // Example: GetFloat64(resource, "spec.threshold") -> (0.95, true, nil)
 func GetFloat64(resource *unstructured.Unstructured, path string) (float64, bool, error) {
-	if path == "" {
-		return 0, false, fmt.Errorf("field path cannot be empty")
+	p, err := Compile(path)
+	if err != nil {
+		return 0, false, err
 	}
-
-	fieldPath := Parse(path)
-	return unstructured.NestedFloat64(resource.Object, fieldPath...)
+	return p.GetFloat64(resource)
 }
 
-// Parse parses a dot-separated field path into a slice for nested field access.
+// Parse splits a dot-separated field path into a slice for unstructured.Nested* helpers.
+// Parse does not understand list indices, filters or quoted keys; use Compile for those.
 // Example: Parse("spec.severity") -> ["spec", "severity"]
-// Example: Parse("status.conditions[0].type") -> ["status", "conditions[0]", "type"]
 func Parse(path string) []string {
 	if path == "" {
 		return nil
@@ -80,13 +82,97 @@ func Parse(path string) []string {
 }
 
 // Exists checks if a field path exists in a resource (regardless of value).
+// Returns false for invalid paths.
 // Example: Exists(resource, "spec.severity") -> true
 func Exists(resource *unstructured.Unstructured, path string) bool {
-	if path == "" {
+	p, err := Compile(path)
+	if err != nil {
 		return false
 	}
+	return p.Exists(resource)
+}
+
+// GetTime retrieves an RFC3339 timestamp from a resource using a field path.
+// Example: GetTime(resource, "status.completionTime") -> (2025-01-01T00:00:00Z, true, nil)
+func GetTime(resource *unstructured.Unstructured, path string) (time.Time, bool, error) {
+	p, err := Compile(path)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return p.GetTime(resource)
+}
 
-	fieldPath := Parse(path)
-	_, found, err := unstructured.NestedFieldNoCopy(resource.Object, fieldPath...)
-	return err == nil && found
+// GetDuration retrieves a Go duration string from a resource using a field path.
+// Example: GetDuration(resource, "spec.timeout") -> (90s, true, nil)
+func GetDuration(resource *unstructured.Unstructured, path string) (time.Duration, bool, error) {
+	p, err := Compile(path)
+	if err != nil {
+		return 0, false, err
+	}
+	return p.GetDuration(resource)
+}
+
+// GetQuantity retrieves a resource.Quantity from a resource using a field path.
+// Example: GetQuantity(resource, "spec.resources.requests.memory") -> (2Gi, true, nil)
+func GetQuantity(resource *unstructured.Unstructured, path string) (apiresource.Quantity, bool, error) {
+	p, err := Compile(path)
+	if err != nil {
+		return apiresource.Quantity{}, false, err
+	}
+	return p.GetQuantity(resource)
+}
+
+// GetStringSlice retrieves a list of strings from a resource using a field path.
+// Example: GetStringSlice(resource, "metadata.finalizers") -> (["example.com/cleanup"], true, nil)
+func GetStringSlice(resource *unstructured.Unstructured, path string) ([]string, bool, error) {
+	p, err := Compile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	return p.GetStringSlice(resource)
+}
+
+// GetMap retrieves a map from a resource using a field path.
+// Example: GetMap(resource, "spec.selector.matchLabels") -> ({"app": "web"}, true, nil)
+func GetMap(resource *unstructured.Unstructured, path string) (map[string]interface{}, bool, error) {
+	p, err := Compile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	return p.GetMap(resource)
+}
+
+// Set stores a value in a resource at a field path, creating intermediate maps as needed.
+// Example: Set(resource, "status.gc.expiresAt", expiresAt)
+func Set(resource *unstructured.Unstructured, path string, value interface{}) error {
+	p, err := Compile(path)
+	if err != nil {
+		return err
+	}
+	if resource.Object == nil {
+		resource.Object = map[string]interface{}{}
+	}
+	return p.Set(resource.Object, value)
+}
+
+// Delete removes a field from a resource using a field path.
+// Returns false if the field does not exist.
+// Example: Delete(resource, `metadata.annotations["gc.kube-zen.io/ttl"]`) -> (true, nil)
+func Delete(resource *unstructured.Unstructured, path string) (bool, error) {
+	p, err := Compile(path)
+	if err != nil {
+		return false, err
+	}
+	return p.Delete(resource.Object)
+}
+
+// JSONPointer converts a field path to an RFC 6901 JSON Pointer for JSON patches.
+// Paths containing filters must be resolved against an object with Path.ResolveJSONPointer.
+// Example: JSONPointer("status.conditions[0].type") -> "/status/conditions/0/type"
+func JSONPointer(path string) (string, error) {
+	p, err := Compile(path)
+	if err != nil {
+		return "", err
+	}
+	return p.JSONPointer()
 }
@@ -0,0 +1,348 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// All typed getters return (value, found, error):
+//   - missing field: (zero, false, nil)
+//   - value that cannot be converted: (zero, false, *Error)
+//
+// Strict paths (the default) only accept the stored type noted on each getter.
+// Lenient paths (see Path.Lenient) additionally accept the conversions noted.
+
+// GetString retrieves a string value at the path.
+// Lenient: numbers and booleans are formatted as strings.
+func (p *Path) GetString(resource *unstructured.Unstructured) (string, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return "", found, err
+	}
+	if s, ok := val.(string); ok {
+		return s, true, nil
+	}
+	if p.lenient {
+		if s, ok := ScalarString(val); ok {
+			return s, true, nil
+		}
+	}
+	return "", false, p.typeError(val, "string", nil)
+}
+
+// GetInt64 retrieves an int64 value at the path.
+// Lenient: integral floats and numeric strings ("3600", "3600.0") are converted.
+func (p *Path) GetInt64(resource *unstructured.Unstructured) (int64, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return 0, found, err
+	}
+	if i, ok := val.(int64); ok {
+		return i, true, nil
+	}
+	if !p.lenient {
+		return 0, false, p.typeError(val, "int64", nil)
+	}
+
+	switch v := val.(type) {
+	case int:
+		return int64(v), true, nil
+	case int32:
+		return int64(v), true, nil
+	case float64:
+		if i, ok := floatToInt64(v); ok {
+			return i, true, nil
+		}
+		return 0, false, p.typeError(val, "int64", fmt.Errorf("%v is not an integer", v))
+	case string:
+		s := strings.TrimSpace(v)
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i, true, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, false, p.typeError(val, "int64", err)
+		}
+		if i, ok := floatToInt64(f); ok {
+			return i, true, nil
+		}
+		return 0, false, p.typeError(val, "int64", fmt.Errorf("%q is not an integer", v))
+	}
+	return 0, false, p.typeError(val, "int64", nil)
+}
+
+// GetFloat64 retrieves a float64 value at the path.
+// Lenient: integers and numeric strings are converted.
+func (p *Path) GetFloat64(resource *unstructured.Unstructured) (float64, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return 0, found, err
+	}
+	if f, ok := val.(float64); ok {
+		return f, true, nil
+	}
+	if !p.lenient {
+		return 0, false, p.typeError(val, "float64", nil)
+	}
+
+	switch v := val.(type) {
+	case int64:
+		return float64(v), true, nil
+	case int:
+		return float64(v), true, nil
+	case int32:
+		return float64(v), true, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, false, p.typeError(val, "float64", err)
+		}
+		return f, true, nil
+	}
+	return 0, false, p.typeError(val, "float64", nil)
+}
+
+// GetBool retrieves a boolean value at the path.
+// Lenient: strings accepted by strconv.ParseBool ("true", "False", "1") are converted.
+func (p *Path) GetBool(resource *unstructured.Unstructured) (bool, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return false, found, err
+	}
+	if b, ok := val.(bool); ok {
+		return b, true, nil
+	}
+	if s, ok := val.(string); ok && p.lenient {
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return false, false, p.typeError(val, "bool", err)
+		}
+		return b, true, nil
+	}
+	return false, false, p.typeError(val, "bool", nil)
+}
+
+// GetTime retrieves an RFC3339 timestamp at the path.
+// Lenient: numbers and numeric strings are interpreted as unix seconds.
+func (p *Path) GetTime(resource *unstructured.Unstructured) (time.Time, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return time.Time{}, found, err
+	}
+
+	if s, ok := val.(string); ok {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+		if err == nil {
+			return t, true, nil
+		}
+		if !p.lenient {
+			return time.Time{}, false, p.segmentError(len(p.segments), "invalid RFC3339 timestamp", err)
+		}
+	}
+	if p.lenient {
+		if seconds, ok := ToFloat64(val); ok {
+			sec, frac := math.Modf(seconds)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), true, nil
+		}
+	}
+	return time.Time{}, false, p.typeError(val, "RFC3339 timestamp", nil)
+}
+
+// GetDuration retrieves a Go duration string ("90s", "1h30m") at the path.
+// Lenient: numbers and numeric strings are interpreted as seconds.
+// For day/week units ("7d") use gc/ttl.ParseDuration on GetString.
+func (p *Path) GetDuration(resource *unstructured.Unstructured) (time.Duration, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return 0, found, err
+	}
+
+	if s, ok := val.(string); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err == nil {
+			return d, true, nil
+		}
+		if !p.lenient {
+			return 0, false, p.segmentError(len(p.segments), "invalid duration", err)
+		}
+	}
+	if p.lenient {
+		if seconds, ok := ToFloat64(val); ok {
+			return time.Duration(seconds * float64(time.Second)), true, nil
+		}
+	}
+	return 0, false, p.typeError(val, "duration", nil)
+}
+
+// GetQuantity retrieves a resource.Quantity ("500m", "2Gi") at the path.
+// Lenient: integers and floats are converted (e.g., cpu: 2).
+func (p *Path) GetQuantity(resource *unstructured.Unstructured) (apiresource.Quantity, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return apiresource.Quantity{}, found, err
+	}
+
+	var s string
+	switch v := val.(type) {
+	case string:
+		s = v
+	case int64, int, int32, float64:
+		if !p.lenient {
+			return apiresource.Quantity{}, false, p.typeError(val, "quantity string", nil)
+		}
+		s, _ = ScalarString(v)
+	default:
+		return apiresource.Quantity{}, false, p.typeError(val, "quantity string", nil)
+	}
+
+	q, err := apiresource.ParseQuantity(strings.TrimSpace(s))
+	if err != nil {
+		return apiresource.Quantity{}, false, p.segmentError(len(p.segments), "invalid quantity", err)
+	}
+	return q, true, nil
+}
+
+// GetStringSlice retrieves a list of strings at the path.
+// Lenient: scalar list elements are formatted as strings and a single scalar
+// value is returned as a one-element slice.
+func (p *Path) GetStringSlice(resource *unstructured.Unstructured) ([]string, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return nil, found, err
+	}
+
+	list, ok := val.([]interface{})
+	if !ok {
+		if p.lenient {
+			if s, ok := ScalarString(val); ok {
+				return []string{s}, true, nil
+			}
+		}
+		return nil, false, p.typeError(val, "list of strings", nil)
+	}
+
+	result := make([]string, 0, len(list))
+	for i, item := range list {
+		s, ok := item.(string)
+		if !ok && p.lenient {
+			s, ok = ScalarString(item)
+		}
+		if !ok {
+			return nil, false, &Error{
+				Path:    p.raw,
+				Segment: p.prefix(len(p.segments)) + "[" + strconv.Itoa(i) + "]",
+				Reason:  fmt.Sprintf("is of the type %T, expected string", item),
+			}
+		}
+		result = append(result, s)
+	}
+	return result, true, nil
+}
+
+// GetMap retrieves a map at the path. The map is not copied.
+// Lenient: a string holding a JSON object (common in annotations) is decoded.
+func (p *Path) GetMap(resource *unstructured.Unstructured) (map[string]interface{}, bool, error) {
+	val, found, err := p.Get(resource.Object)
+	if !found || err != nil {
+		return nil, found, err
+	}
+
+	if m, ok := val.(map[string]interface{}); ok {
+		return m, true, nil
+	}
+	if s, ok := val.(string); ok && p.lenient {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			return nil, false, p.segmentError(len(p.segments), "invalid JSON object", err)
+		}
+		return m, true, nil
+	}
+	return nil, false, p.typeError(val, "map", nil)
+}
+
+// Exists checks if the path exists in a resource (regardless of value).
+func (p *Path) Exists(resource *unstructured.Unstructured) bool {
+	_, found, err := p.Get(resource.Object)
+	return err == nil && found
+}
+
+// typeError returns an Error for a value of the wrong type at the final segment.
+func (p *Path) typeError(val interface{}, expected string, cause error) *Error {
+	return p.segmentError(len(p.segments), fmt.Sprintf("is of the type %T, expected %s", val, expected), cause)
+}
+
+// ScalarString returns the string form of a scalar value, so filters like
+// [?port==8080] and [?ready==true] match numeric and boolean elements.
+// Lists, maps and nil are not scalars and return false.
+func ScalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case int:
+		return strconv.Itoa(v), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	default:
+		return "", false
+	}
+}
+
+// ToFloat64 converts a number or numeric string to float64.
+func ToFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// floatToInt64 converts an integral float64 to int64 without loss.
+func floatToInt64(f float64) (int64, bool) {
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldpath
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newTypedResource() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					"ttlSeconds": "3600",
+					"enabled":    "true",
+					"config":     `{"mode":"fast"}`,
+				},
+				"finalizers": []interface{}{"a", "b"},
+			},
+			"spec": map[string]interface{}{
+				"replicas":  float64(3),
+				"ratio":     int64(2),
+				"timeout":   "90s",
+				"timeoutN":  int64(30),
+				"memory":    "2Gi",
+				"cpu":       int64(2),
+				"ports":     []interface{}{int64(80), int64(443)},
+				"half":      1.5,
+				"selector":  map[string]interface{}{"app": "web"},
+				"createdAt": "2025-01-02T03:04:05Z",
+				"createdN":  int64(1735787045),
+			},
+		},
+	}
+}
+
+func TestGetters_Strict(t *testing.T) {
+	resource := newTypedResource()
+
+	ts, found, err := GetTime(resource, "spec.createdAt")
+	if err != nil || !found || !ts.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("GetTime() = %v, %v, %v", ts, found, err)
+	}
+
+	d, found, err := GetDuration(resource, "spec.timeout")
+	if err != nil || !found || d != 90*time.Second {
+		t.Errorf("GetDuration() = %v, %v, %v", d, found, err)
+	}
+
+	q, found, err := GetQuantity(resource, "spec.memory")
+	if err != nil || !found || q.Value() != 2*1024*1024*1024 {
+		t.Errorf("GetQuantity() = %v, %v, %v", q.String(), found, err)
+	}
+
+	slice, found, err := GetStringSlice(resource, "metadata.finalizers")
+	if err != nil || !found || !reflect.DeepEqual(slice, []string{"a", "b"}) {
+		t.Errorf("GetStringSlice() = %v, %v, %v", slice, found, err)
+	}
+
+	m, found, err := GetMap(resource, "spec.selector")
+	if err != nil || !found || m["app"] != "web" {
+		t.Errorf("GetMap() = %v, %v, %v", m, found, err)
+	}
+
+	// Strict mode rejects representations that need coercion
+	strictFailures := []func() error{
+		func() error { _, _, err := GetInt64(resource, "spec.replicas"); return err },
+		func() error { _, _, err := GetInt64(resource, "metadata.annotations.ttlSeconds"); return err },
+		func() error { _, _, err := GetFloat64(resource, "spec.ratio"); return err },
+		func() error { _, _, err := GetBool(resource, "metadata.annotations.enabled"); return err },
+		func() error { _, _, err := GetTime(resource, "spec.createdN"); return err },
+		func() error { _, _, err := GetDuration(resource, "spec.timeoutN"); return err },
+		func() error { _, _, err := GetQuantity(resource, "spec.cpu"); return err },
+		func() error { _, _, err := GetStringSlice(resource, "spec.ports"); return err },
+		func() error { _, _, err := GetMap(resource, "metadata.annotations.config"); return err },
+	}
+	for i, f := range strictFailures {
+		var pathErr *Error
+		if err := f(); !errors.As(err, &pathErr) {
+			t.Errorf("strict case %d: error = %v, want *Error", i, err)
+		}
+	}
+}
+
+func TestGetters_Lenient(t *testing.T) {
+	resource := newTypedResource()
+	lenient := func(path string) *Path { return MustCompile(path).Lenient() }
+
+	if i, found, err := lenient("spec.replicas").GetInt64(resource); err != nil || !found || i != 3 {
+		t.Errorf("GetInt64(float) = %d, %v, %v", i, found, err)
+	}
+	if i, found, err := lenient("metadata.annotations.ttlSeconds").GetInt64(resource); err != nil || !found || i != 3600 {
+		t.Errorf("GetInt64(string) = %d, %v, %v", i, found, err)
+	}
+	if _, _, err := lenient("spec.half").GetInt64(resource); err == nil {
+		t.Error("GetInt64(1.5) expected error for non-integral value")
+	}
+	if f, found, err := lenient("spec.ratio").GetFloat64(resource); err != nil || !found || f != 2 {
+		t.Errorf("GetFloat64(int) = %v, %v, %v", f, found, err)
+	}
+	if b, found, err := lenient("metadata.annotations.enabled").GetBool(resource); err != nil || !found || !b {
+		t.Errorf("GetBool(string) = %v, %v, %v", b, found, err)
+	}
+	if s, found, err := lenient("spec.ratio").GetString(resource); err != nil || !found || s != "2" {
+		t.Errorf("GetString(int) = %q, %v, %v", s, found, err)
+	}
+	if ts, found, err := lenient("spec.createdN").GetTime(resource); err != nil || !found || !ts.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("GetTime(unix) = %v, %v, %v", ts, found, err)
+	}
+	if d, found, err := lenient("spec.timeoutN").GetDuration(resource); err != nil || !found || d != 30*time.Second {
+		t.Errorf("GetDuration(int) = %v, %v, %v", d, found, err)
+	}
+	if q, found, err := lenient("spec.cpu").GetQuantity(resource); err != nil || !found || q.Value() != 2 {
+		t.Errorf("GetQuantity(int) = %v, %v, %v", q.String(), found, err)
+	}
+	if s, found, err := lenient("spec.ports").GetStringSlice(resource); err != nil || !found || !reflect.DeepEqual(s, []string{"80", "443"}) {
+		t.Errorf("GetStringSlice(ints) = %v, %v, %v", s, found, err)
+	}
+	if s, found, err := lenient("spec.memory").GetStringSlice(resource); err != nil || !found || !reflect.DeepEqual(s, []string{"2Gi"}) {
+		t.Errorf("GetStringSlice(scalar) = %v, %v, %v", s, found, err)
+	}
+	if m, found, err := lenient("metadata.annotations.config").GetMap(resource); err != nil || !found || m["mode"] != "fast" {
+		t.Errorf("GetMap(JSON string) = %v, %v, %v", m, found, err)
+	}
+}
+
+func TestGetters_MissingField(t *testing.T) {
+	resource := newTypedResource()
+
+	if _, found, err := GetTime(resource, "spec.missing"); found || err != nil {
+		t.Errorf("GetTime(missing) = %v, %v, want false, nil", found, err)
+	}
+	if _, found, err := MustCompile("spec.missing").Lenient().GetInt64(resource); found || err != nil {
+		t.Errorf("GetInt64(missing) = %v, %v, want false, nil", found, err)
+	}
+}
+
+func TestError_DescribesSegment(t *testing.T) {
+	resource := newTypedResource()
+
+	// Traversal failure points at the segment that could not be entered
+	_, _, err := GetString(resource, "spec.timeout.value")
+	var pathErr *Error
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("error = %v, want *Error", err)
+	}
+	if pathErr.Segment != "spec.timeout.value" || !strings.Contains(pathErr.Reason, "expected map") {
+		t.Errorf("Error = %+v", pathErr)
+	}
+
+	// Element failure points at the list element
+	_, _, err = MustCompile("spec.ports").GetStringSlice(resource)
+	if !errors.As(err, &pathErr) || pathErr.Segment != "spec.ports[0]" {
+		t.Errorf("GetStringSlice error = %v, want segment spec.ports[0]", err)
+	}
+
+	// Parse failures keep the underlying cause
+	_, _, err = MustCompile("spec.memory").GetDuration(resource)
+	if !errors.As(err, &pathErr) || pathErr.Err == nil || !strings.Contains(err.Error(), `"spec.memory"`) {
+		t.Errorf("GetDuration error = %v, want cause and path", err)
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+)
+
+// Set stores value at the path, creating intermediate maps as needed.
+//
+// Lists are never created: index segments must be within range and filter
+// segments must match an existing element. The value is converted to its
+// unstructured JSON form first, so Go ints become int64, time.Time becomes
+// an RFC3339 string and structs are marshalled; maps and slices are copied.
+func (p *Path) Set(obj map[string]interface{}, value interface{}) error {
+	if obj == nil {
+		return p.segmentError(0, "cannot set a field on a nil object", nil)
+	}
+	jsonValue, err := toJSONValue(value)
+	if err != nil {
+		return p.segmentError(len(p.segments), "value cannot be converted to JSON", err)
+	}
+	_, err = p.set(obj, 0, jsonValue)
+	return err
+}
+
+// set stores value below container starting at segment i and returns the
+// updated container (a new map when container was nil).
+func (p *Path) set(container interface{}, i int, value interface{}) (interface{}, error) {
+	if i == len(p.segments) {
+		return value, nil
+	}
+
+	switch seg := p.segments[i]; seg.kind {
+	case fieldSegment:
+		if container == nil {
+			container = map[string]interface{}{}
+		}
+		m, ok := container.(map[string]interface{})
+		if !ok {
+			return nil, p.segmentError(i+1, fmt.Sprintf("parent is of the type %T, expected map", container), nil)
+		}
+		child, err := p.set(m[seg.key], i+1, value)
+		if err != nil {
+			return nil, err
+		}
+		m[seg.key] = child
+		return m, nil
+
+	default:
+		list, ok := container.([]interface{})
+		if !ok {
+			return nil, p.segmentError(i+1, fmt.Sprintf("parent is of the type %T, expected list", container), nil)
+		}
+		index, found := elementIndex(list, seg)
+		if !found {
+			return nil, p.segmentError(i+1, "no such list element", nil)
+		}
+		child, err := p.set(list[index], i+1, value)
+		if err != nil {
+			return nil, err
+		}
+		list[index] = child
+		return list, nil
+	}
+}
+
+// Delete removes the field or list element at the path.
+// Returns false if the path does not exist, and an error if a segment cannot
+// be traversed (e.g., indexing into a map). Deleting a list element shifts
+// the elements after it.
+func (p *Path) Delete(obj map[string]interface{}) (bool, error) {
+	if obj == nil {
+		return false, nil
+	}
+	_, deleted, err := p.delete(obj, 0)
+	return deleted, err
+}
+
+// delete removes the target below container starting at segment i and returns
+// the updated container.
+func (p *Path) delete(container interface{}, i int) (interface{}, bool, error) {
+	if container == nil {
+		return nil, false, nil
+	}
+	last := i == len(p.segments)-1
+
+	switch seg := p.segments[i]; seg.kind {
+	case fieldSegment:
+		m, ok := container.(map[string]interface{})
+		if !ok {
+			return container, false, p.segmentError(i+1, fmt.Sprintf("parent is of the type %T, expected map", container), nil)
+		}
+		child, exists := m[seg.key]
+		if !exists {
+			return m, false, nil
+		}
+		if last {
+			delete(m, seg.key)
+			return m, true, nil
+		}
+		child, deleted, err := p.delete(child, i+1)
+		if deleted {
+			m[seg.key] = child
+		}
+		return m, deleted, err
+
+	default:
+		list, ok := container.([]interface{})
+		if !ok {
+			return container, false, p.segmentError(i+1, fmt.Sprintf("parent is of the type %T, expected list", container), nil)
+		}
+		index, found := elementIndex(list, seg)
+		if !found {
+			return list, false, nil
+		}
+		if last {
+			return append(list[:index:index], list[index+1:]...), true, nil
+		}
+		child, deleted, err := p.delete(list[index], i+1)
+		if deleted {
+			list[index] = child
+		}
+		return list, deleted, err
+	}
+}
+
+// elementIndex returns the position of the list element selected by an index
+// or filter segment. Filters select the first map element whose key has the filter value.
+func elementIndex(list []interface{}, seg segment) (int, bool) {
+	if seg.kind == indexSegment {
+		return seg.index, seg.index < len(list)
+	}
+	for i, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if str, ok := ScalarString(m[seg.key]); ok && str == seg.value {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// JSONPointer renders the path as an RFC 6901 JSON Pointer for use in JSON
+// patches (e.g., webhook.GenerateAddPatch). Filter segments have no pointer
+// form and return an error; use ResolveJSONPointer to resolve them against an object.
+// Example: `metadata.annotations["example.com/ttl"]` -> "/metadata/annotations/example.com~1ttl"
+func (p *Path) JSONPointer() (string, error) {
+	var b strings.Builder
+	for i, seg := range p.segments {
+		switch seg.kind {
+		case fieldSegment:
+			b.WriteByte('/')
+			b.WriteString(escapeJSONPointer(seg.key))
+		case indexSegment:
+			b.WriteByte('/')
+			b.WriteString(strconv.Itoa(seg.index))
+		default:
+			return "", p.segmentError(i+1, "filter has no JSON Pointer form", nil)
+		}
+	}
+	return b.String(), nil
+}
+
+// ResolveJSONPointer renders the path as an RFC 6901 JSON Pointer, replacing
+// filter segments with the index of the matching element in obj.
+// Returns false if a filter matches no element.
+func (p *Path) ResolveJSONPointer(obj map[string]interface{}) (string, bool, error) {
+	var b strings.Builder
+	var val interface{} = obj
+
+	for i, seg := range p.segments {
+		switch seg.kind {
+		case fieldSegment:
+			b.WriteByte('/')
+			b.WriteString(escapeJSONPointer(seg.key))
+			if m, ok := val.(map[string]interface{}); ok {
+				val = m[seg.key]
+			} else {
+				val = nil
+			}
+
+		case indexSegment:
+			b.WriteByte('/')
+			b.WriteString(strconv.Itoa(seg.index))
+			if list, ok := val.([]interface{}); ok && seg.index < len(list) {
+				val = list[seg.index]
+			} else {
+				val = nil
+			}
+
+		case filterSegment:
+			list, ok := val.([]interface{})
+			if !ok {
+				if val == nil {
+					return "", false, nil
+				}
+				return "", false, p.segmentError(i+1, fmt.Sprintf("parent is of the type %T, expected list", val), nil)
+			}
+			index, found := elementIndex(list, seg)
+			if !found {
+				return "", false, nil
+			}
+			b.WriteByte('/')
+			b.WriteString(strconv.Itoa(index))
+			val = list[index]
+		}
+	}
+	return b.String(), true, nil
+}
+
+// escapeJSONPointer escapes a reference token per RFC 6901 ("~" -> "~0", "/" -> "~1").
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// toJSONValue converts a Go value to the form stored in unstructured objects
+// (string, bool, int64, float64, nil, []interface{}, map[string]interface{}).
+func toJSONValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, string, bool, int64, float64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	case time.Time:
+		return v.UTC().Format(time.RFC3339), nil
+	case time.Duration:
+		return v.String(), nil
+	}
+
+	// Composite and custom types round-trip through JSON, which copies them and
+	// decodes numbers as int64/float64 like the unstructured decoder.
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := utiljson.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
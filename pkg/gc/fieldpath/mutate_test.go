@@ -0,0 +1,183 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldpath
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSet_CreatesIntermediateMaps(t *testing.T) {
+	resource := newPodResource()
+	expiresAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := Set(resource, "status.gc.expiresAt", expiresAt); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+	got, found, err := GetTime(resource, "status.gc.expiresAt")
+	if err != nil || !found || !got.Equal(expiresAt) {
+		t.Errorf("GetTime() after Set = %v, %v, %v", got, found, err)
+	}
+
+	if err := Set(resource, `metadata.labels["app.kubernetes.io/name"]`, "web"); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+	if resource.GetLabels()["app.kubernetes.io/name"] != "web" {
+		t.Errorf("labels = %v", resource.GetLabels())
+	}
+
+	empty := &unstructured.Unstructured{}
+	if err := Set(empty, "spec.replicas", 3); err != nil {
+		t.Fatalf("Set() on empty object unexpected error: %v", err)
+	}
+	if replicas, _, _ := GetInt64(empty, "spec.replicas"); replicas != 3 {
+		t.Errorf("spec.replicas = %d, want 3 (int converted to int64)", replicas)
+	}
+}
+
+func TestSet_ListElements(t *testing.T) {
+	resource := newPodResource()
+
+	if err := Set(resource, `status.conditions[?type=="Ready"].status`, "True"); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+	if status, _, _ := GetString(resource, "status.conditions[1].status"); status != "True" {
+		t.Errorf("status.conditions[1].status = %q, want True", status)
+	}
+
+	if err := Set(resource, "spec.containers[0].ports[0].containerPort", 9090); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+	if port, _, _ := GetInt64(resource, "spec.containers[0].ports[0].containerPort"); port != 9090 {
+		t.Errorf("containerPort = %d, want 9090", port)
+	}
+
+	if err := Set(resource, "spec.containers", []string{"a"}); err != nil {
+		t.Fatalf("Set() unexpected error: %v", err)
+	}
+	if got, _, _ := GetStringSlice(resource, "spec.containers"); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("spec.containers = %v", got)
+	}
+}
+
+func TestSet_Errors(t *testing.T) {
+	for _, path := range []string{
+		"status.conditions[5].type",                              // index out of range
+		`status.conditions[?type=="Scheduled"].type`,             // no matching element
+		"spec.newList[0]",                                        // lists are not created
+		`metadata.annotations["argocd.argoproj.io/sync-wave"].x`, // traverses into a string
+	} {
+		resource := newPodResource()
+		var pathErr *Error
+		if err := Set(resource, path, "x"); !errors.As(err, &pathErr) {
+			t.Errorf("Set(%q) error = %v, want *Error", path, err)
+		}
+	}
+
+	resource := newPodResource()
+	if err := Set(resource, "spec.invalid", make(chan int)); err == nil {
+		t.Error("Set() with non-JSON value expected error")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	resource := newPodResource()
+
+	deleted, err := Delete(resource, `metadata.annotations["argocd.argoproj.io/sync-wave"]`)
+	if err != nil || !deleted {
+		t.Errorf("Delete(annotation) = %v, %v", deleted, err)
+	}
+	if Exists(resource, `metadata.annotations["argocd.argoproj.io/sync-wave"]`) {
+		t.Error("annotation still exists after Delete")
+	}
+
+	deleted, err = Delete(resource, `status.conditions[?type=="Initialized"]`)
+	if err != nil || !deleted {
+		t.Errorf("Delete(condition) = %v, %v", deleted, err)
+	}
+	if typ, _, _ := GetString(resource, "status.conditions[0].type"); typ != "Ready" {
+		t.Errorf("status.conditions[0].type = %q, want Ready after removing Initialized", typ)
+	}
+
+	deleted, err = Delete(resource, "spec.containers[1].name")
+	if err != nil || !deleted {
+		t.Errorf("Delete(nested in list) = %v, %v", deleted, err)
+	}
+	if Exists(resource, "spec.containers[1].name") {
+		t.Error("spec.containers[1].name still exists after Delete")
+	}
+
+	for _, path := range []string{"spec.missing.field", "status.conditions[9]", `status.conditions[?type=="Scheduled"]`} {
+		if deleted, err := Delete(resource, path); deleted || err != nil {
+			t.Errorf("Delete(%q) = %v, %v, want false, nil", path, deleted, err)
+		}
+	}
+
+	if _, err := Delete(resource, "metadata.annotations[0]"); err == nil {
+		t.Error("Delete() with index on map expected error")
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "spec.severity", want: "/spec/severity"},
+		{path: "status.conditions[0].type", want: "/status/conditions/0/type"},
+		{path: `metadata.annotations["argocd.argoproj.io/sync-wave"]`, want: "/metadata/annotations/argocd.argoproj.io~1sync-wave"},
+		{path: `metadata.labels["a~b"]`, want: "/metadata/labels/a~0b"},
+	}
+	for _, tt := range tests {
+		got, err := JSONPointer(tt.path)
+		if err != nil {
+			t.Errorf("JSONPointer(%q) unexpected error: %v", tt.path, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("JSONPointer(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	if _, err := JSONPointer(`status.conditions[?type=="Ready"].status`); err == nil {
+		t.Error("JSONPointer() with filter expected error")
+	}
+}
+
+func TestResolveJSONPointer(t *testing.T) {
+	resource := newPodResource()
+
+	p := MustCompile(`status.conditions[?type=="Ready"].status`)
+	got, found, err := p.ResolveJSONPointer(resource.Object)
+	if err != nil || !found || got != "/status/conditions/1/status" {
+		t.Errorf("ResolveJSONPointer() = %q, %v, %v", got, found, err)
+	}
+
+	// Fields that do not exist yet still resolve, for "add" operations
+	got, found, err = MustCompile("status.gc.expiresAt").ResolveJSONPointer(resource.Object)
+	if err != nil || !found || got != "/status/gc/expiresAt" {
+		t.Errorf("ResolveJSONPointer(new field) = %q, %v, %v", got, found, err)
+	}
+
+	if _, found, _ := MustCompile(`status.conditions[?type=="Scheduled"].status`).ResolveJSONPointer(resource.Object); found {
+		t.Error("ResolveJSONPointer() with unmatched filter = found, want not found")
+	}
+}
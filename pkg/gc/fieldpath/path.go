@@ -0,0 +1,357 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldpath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPath indicates a field path could not be compiled.
+var ErrInvalidPath = errors.New("invalid field path")
+
+// Error describes a failed lookup: which segment of the path failed and why.
+type Error struct {
+	// Path is the full field path.
+	Path string
+
+	// Segment is the path up to and including the segment that failed.
+	Segment string
+
+	// Reason explains the failure (e.g., "is of the type string, expected int64").
+	Reason string
+
+	// Err is the underlying cause, if any (e.g., a parse error).
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("field path %q: segment %q: %s", e.Path, e.Segment, e.Reason)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// segmentKind identifies the type of a path segment.
+type segmentKind int
+
+const (
+	// fieldSegment selects a map key.
+	fieldSegment segmentKind = iota
+	// indexSegment selects a list element by position.
+	indexSegment
+	// filterSegment selects the first list element whose key equals a value.
+	filterSegment
+)
+
+// segment is one step of a compiled path.
+type segment struct {
+	kind  segmentKind
+	key   string // map key (fieldSegment) or element key (filterSegment)
+	index int    // list index (indexSegment)
+	value string // expected element value (filterSegment)
+}
+
+// String renders the segment in path syntax.
+func (s segment) String() string {
+	switch s.kind {
+	case indexSegment:
+		return "[" + strconv.Itoa(s.index) + "]"
+	case filterSegment:
+		return "[?" + s.key + "==" + strconv.Quote(s.value) + "]"
+	default:
+		if s.key == "" || strings.ContainsAny(s.key, ".[]\"'") {
+			return "[" + strconv.Quote(s.key) + "]"
+		}
+		return s.key
+	}
+}
+
+// Path is a compiled field path. Compile a path once and reuse it across lookups.
+// A Path is immutable and safe for concurrent use.
+//
+// Grammar:
+//   - Dot-separated map keys: "spec.severity"
+//   - List indices: "status.conditions[0].type"
+//   - Bracket-quoted keys containing dots or slashes:
+//     `metadata.annotations["argocd.argoproj.io/sync-wave"]` (single quotes also work)
+//   - List filters selecting the first element whose key equals a value:
+//     `status.conditions[?type=="Ready"].status`
+type Path struct {
+	raw      string
+	segments []segment
+	lenient  bool
+}
+
+// Compile parses a field path. Errors wrap ErrInvalidPath.
+func Compile(path string) (*Path, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: field path cannot be empty", ErrInvalidPath)
+	}
+
+	const (
+		stateStart = iota
+		stateAfterDot
+		stateAfterSegment
+	)
+
+	p := &Path{raw: path}
+	state := stateStart
+	for i := 0; i < len(path); {
+		switch c := path[i]; {
+		case c == '.':
+			if state != stateAfterSegment {
+				return nil, fmt.Errorf("%w: %q: empty segment at offset %d", ErrInvalidPath, path, i)
+			}
+			state = stateAfterDot
+			i++
+
+		case c == '[':
+			if state == stateAfterDot {
+				return nil, fmt.Errorf("%w: %q: unexpected '[' after '.' at offset %d", ErrInvalidPath, path, i)
+			}
+			seg, next, err := parseBracket(path, i)
+			if err != nil {
+				return nil, err
+			}
+			p.segments = append(p.segments, seg)
+			state = stateAfterSegment
+			i = next
+
+		case c == ']':
+			return nil, fmt.Errorf("%w: %q: unexpected ']' at offset %d", ErrInvalidPath, path, i)
+
+		default:
+			if state == stateAfterSegment {
+				return nil, fmt.Errorf("%w: %q: expected '.' or '[' at offset %d", ErrInvalidPath, path, i)
+			}
+			end := i
+			for end < len(path) && path[end] != '.' && path[end] != '[' && path[end] != ']' {
+				end++
+			}
+			p.segments = append(p.segments, segment{kind: fieldSegment, key: path[i:end]})
+			state = stateAfterSegment
+			i = end
+		}
+	}
+
+	if state != stateAfterSegment {
+		return nil, fmt.Errorf("%w: %q: trailing '.'", ErrInvalidPath, path)
+	}
+	return p, nil
+}
+
+// MustCompile is like Compile but panics if the path is invalid.
+// Intended for package-level variables holding constant paths.
+func MustCompile(path string) *Path {
+	p, err := Compile(path)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// parseBracket parses a bracket expression starting at path[start] == '['.
+// Returns the segment and the offset just past the closing ']'.
+func parseBracket(path string, start int) (segment, int, error) {
+	i := start + 1
+	if i >= len(path) {
+		return segment{}, 0, fmt.Errorf("%w: %q: unterminated '[' at offset %d", ErrInvalidPath, path, start)
+	}
+
+	var seg segment
+	switch c := path[i]; {
+	case c == '"' || c == '\'':
+		key, next, err := parseQuoted(path, i)
+		if err != nil {
+			return segment{}, 0, err
+		}
+		seg = segment{kind: fieldSegment, key: key}
+		i = next
+
+	case c == '?':
+		i++
+		keyStart := i
+		for i < len(path) && path[i] != '=' && path[i] != ']' {
+			i++
+		}
+		key := strings.TrimSpace(path[keyStart:i])
+		if key == "" || !strings.HasPrefix(path[i:], "==") {
+			return segment{}, 0, fmt.Errorf("%w: %q: filter at offset %d must have the form [?key==\"value\"]", ErrInvalidPath, path, start)
+		}
+		i += len("==")
+		for i < len(path) && path[i] == ' ' {
+			i++
+		}
+		var value string
+		if i < len(path) && (path[i] == '"' || path[i] == '\'') {
+			v, next, err := parseQuoted(path, i)
+			if err != nil {
+				return segment{}, 0, err
+			}
+			value, i = v, next
+		} else {
+			valueStart := i
+			for i < len(path) && path[i] != ']' {
+				i++
+			}
+			value = strings.TrimSpace(path[valueStart:i])
+		}
+		if value == "" {
+			return segment{}, 0, fmt.Errorf("%w: %q: filter at offset %d has no value", ErrInvalidPath, path, start)
+		}
+		seg = segment{kind: filterSegment, key: key, value: value}
+
+	case c >= '0' && c <= '9':
+		indexStart := i
+		for i < len(path) && path[i] >= '0' && path[i] <= '9' {
+			i++
+		}
+		index, err := strconv.Atoi(path[indexStart:i])
+		if err != nil {
+			return segment{}, 0, fmt.Errorf("%w: %q: invalid index at offset %d", ErrInvalidPath, path, indexStart)
+		}
+		seg = segment{kind: indexSegment, index: index}
+
+	default:
+		return segment{}, 0, fmt.Errorf("%w: %q: expected index, quoted key or filter at offset %d", ErrInvalidPath, path, i)
+	}
+
+	for i < len(path) && path[i] == ' ' {
+		i++
+	}
+	if i >= len(path) || path[i] != ']' {
+		return segment{}, 0, fmt.Errorf("%w: %q: unterminated '[' at offset %d", ErrInvalidPath, path, start)
+	}
+	return seg, i + 1, nil
+}
+
+// parseQuoted parses a single- or double-quoted string starting at path[start].
+// A backslash escapes the next character. Returns the unquoted string and the
+// offset just past the closing quote.
+func parseQuoted(path string, start int) (string, int, error) {
+	quote := path[start]
+	var b strings.Builder
+	for i := start + 1; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			if i+1 < len(path) {
+				i++
+				b.WriteByte(path[i])
+			}
+		case quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(path[i])
+		}
+	}
+	return "", 0, fmt.Errorf("%w: %q: unterminated quote at offset %d", ErrInvalidPath, path, start)
+}
+
+// String returns the path as originally written.
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Lenient returns a copy of the path whose typed getters coerce between
+// compatible representations, e.g. int64 <-> float64, quoted numbers
+// ("3600", common in annotations and int-or-string fields) and unix timestamps.
+// The default (strict) getters require the stored value to already have the requested type.
+func (p *Path) Lenient() *Path {
+	c := *p
+	c.lenient = true
+	return &c
+}
+
+// IsLenient reports whether typed getters coerce values.
+func (p *Path) IsLenient() bool {
+	return p.lenient
+}
+
+// segmentError returns an Error for the first n segments.
+func (p *Path) segmentError(n int, reason string, cause error) *Error {
+	return &Error{Path: p.raw, Segment: p.prefix(n), Reason: reason, Err: cause}
+}
+
+// prefix renders the first n segments, for error messages.
+func (p *Path) prefix(n int) string {
+	var b strings.Builder
+	for i, seg := range p.segments[:n] {
+		s := seg.String()
+		if i > 0 && !strings.HasPrefix(s, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// Get returns the value at the path without copying.
+// Returns false if a segment is missing (absent key, index out of range or no
+// element matching a filter) and an error if a segment cannot be traversed
+// (e.g., indexing into a map).
+func (p *Path) Get(obj map[string]interface{}) (interface{}, bool, error) {
+	var val interface{} = obj
+
+	for i, seg := range p.segments {
+		if val == nil {
+			return nil, false, nil
+		}
+
+		switch seg.kind {
+		case fieldSegment:
+			m, ok := val.(map[string]interface{})
+			if !ok {
+				return nil, false, p.segmentError(i+1, fmt.Sprintf("parent is of the type %T, expected map", val), nil)
+			}
+			if val, ok = m[seg.key]; !ok {
+				return nil, false, nil
+			}
+
+		case indexSegment:
+			list, ok := val.([]interface{})
+			if !ok {
+				return nil, false, p.segmentError(i+1, fmt.Sprintf("parent is of the type %T, expected list", val), nil)
+			}
+			if seg.index >= len(list) {
+				return nil, false, nil
+			}
+			val = list[seg.index]
+
+		case filterSegment:
+			list, ok := val.([]interface{})
+			if !ok {
+				return nil, false, p.segmentError(i+1, fmt.Sprintf("parent is of the type %T, expected list", val), nil)
+			}
+			index, found := elementIndex(list, seg)
+			if !found {
+				return nil, false, nil
+			}
+			val = list[index]
+		}
+	}
+	return val, true, nil
+}
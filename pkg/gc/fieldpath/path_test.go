@@ -0,0 +1,206 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldpath
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newPodResource() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					"argocd.argoproj.io/sync-wave": "5",
+				},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{
+						"name": "app",
+						"ports": []interface{}{
+							map[string]interface{}{"containerPort": int64(8080)},
+						},
+					},
+					map[string]interface{}{"name": "sidecar"},
+				},
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Initialized", "status": "True"},
+					map[string]interface{}{"type": "Ready", "status": "False"},
+				},
+				"containerStatuses": []interface{}{
+					map[string]interface{}{"name": "app", "ready": true, "restartCount": int64(6)},
+				},
+			},
+		},
+	}
+}
+
+func TestPath_Get(t *testing.T) {
+	resource := newPodResource()
+
+	tests := []struct {
+		path string
+		want interface{}
+	}{
+		{path: "status.conditions[0].type", want: "Initialized"},
+		{path: "status.conditions[1].status", want: "False"},
+		{path: `status.conditions[?type=="Ready"].status`, want: "False"},
+		{path: `status.conditions[?type=='Initialized'].status`, want: "True"},
+		{path: `status.conditions[?type == "Ready"].status`, want: "False"},
+		{path: `status.containerStatuses[?ready==true].restartCount`, want: int64(6)},
+		{path: `metadata.annotations["argocd.argoproj.io/sync-wave"]`, want: "5"},
+		{path: `metadata.annotations['argocd.argoproj.io/sync-wave']`, want: "5"},
+		{path: `["metadata"].annotations["argocd.argoproj.io/sync-wave"]`, want: "5"},
+		{path: "spec.containers[0].ports[0].containerPort", want: int64(8080)},
+		{path: `spec.containers[?name=="sidecar"].name`, want: "sidecar"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := Compile(tt.path)
+			if err != nil {
+				t.Fatalf("Compile(%q) unexpected error: %v", tt.path, err)
+			}
+			got, found, err := p.Get(resource.Object)
+			if err != nil {
+				t.Fatalf("Get() unexpected error: %v", err)
+			}
+			if !found {
+				t.Fatal("expected field to be found")
+			}
+			if got != tt.want {
+				t.Errorf("Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPath_Get_NotFound(t *testing.T) {
+	resource := newPodResource()
+
+	for _, path := range []string{
+		"status.conditions[5].type",
+		`status.conditions[?type=="Scheduled"].status`,
+		`metadata.annotations["missing.example.com/key"]`,
+		"spec.missing.field",
+	} {
+		p := MustCompile(path)
+		_, found, err := p.Get(resource.Object)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", path, err)
+		}
+		if found {
+			t.Errorf("%s: expected field to not be found", path)
+		}
+	}
+}
+
+func TestPath_Get_TraversalError(t *testing.T) {
+	resource := newPodResource()
+
+	for _, path := range []string{
+		"status.conditions.type",   // map access on a list
+		"metadata.annotations[0]",  // index on a map
+		`spec[?name=="app"].ports`, // filter on a map
+	} {
+		p := MustCompile(path)
+		if _, _, err := p.Get(resource.Object); err == nil {
+			t.Errorf("%s: expected traversal error", path)
+		}
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	for _, path := range []string{
+		"",
+		".spec",
+		"spec.",
+		"spec..severity",
+		"spec.[0]",
+		"status.conditions[",
+		"status.conditions[0",
+		"status.conditions[x]",
+		"status.conditions]",
+		"status.conditions[0]type",
+		`metadata.annotations["unterminated]`,
+		`status.conditions[?type]`,
+		`status.conditions[?=="Ready"]`,
+		`status.conditions[?type==]`,
+	} {
+		if _, err := Compile(path); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("Compile(%q) error = %v, want ErrInvalidPath", path, err)
+		}
+	}
+}
+
+func TestMustCompile_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustCompile to panic on invalid path")
+		}
+	}()
+	MustCompile("spec..severity")
+}
+
+func TestPath_TypedGetters(t *testing.T) {
+	resource := newPodResource()
+
+	status, found, err := MustCompile(`status.conditions[?type=="Ready"].status`).GetString(resource)
+	if err != nil || !found || status != "False" {
+		t.Errorf("GetString() = %q, %v, %v", status, found, err)
+	}
+
+	restarts, found, err := MustCompile("status.containerStatuses[0].restartCount").GetInt64(resource)
+	if err != nil || !found || restarts != 6 {
+		t.Errorf("GetInt64() = %d, %v, %v", restarts, found, err)
+	}
+
+	ready, found, err := MustCompile("status.containerStatuses[0].ready").GetBool(resource)
+	if err != nil || !found || !ready {
+		t.Errorf("GetBool() = %v, %v, %v", ready, found, err)
+	}
+
+	if _, _, err := MustCompile("status.containerStatuses[0].ready").GetString(resource); err == nil {
+		t.Error("GetString() on bool field expected type error")
+	}
+
+	if !MustCompile(`metadata.annotations["argocd.argoproj.io/sync-wave"]`).Exists(resource) {
+		t.Error("Exists() = false, want true")
+	}
+}
+
+func TestGetString_IndexedPath(t *testing.T) {
+	resource := newPodResource()
+
+	value, found, err := GetString(resource, "status.conditions[0].type")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !found || value != "Initialized" {
+		t.Errorf("GetString() = %q, %v, want Initialized, true", value, found)
+	}
+
+	if Exists(resource, "spec..invalid") {
+		t.Error("Exists() on invalid path = true, want false")
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package idle provides the keyed state map shared by backoff.Registry and
+// ratelimiter.KeyedRateLimiter: entries are created on first use and evicted
+// once they have not been used for a timeout.
+package idle
+
+import "time"
+
+// Map holds one value per key and evicts keys not used within its timeout.
+// It is not safe for concurrent use; callers guard it with their own lock.
+type Map[V any] struct {
+	timeout   time.Duration
+	now       func() time.Time
+	onEvict   func(key string)
+	entries   map[string]*entry[V]
+	lastSweep time.Time
+}
+
+// entry is a value and the time it was last used.
+type entry[V any] struct {
+	value    V
+	lastUsed time.Time
+}
+
+// NewMap creates a Map evicting keys idle for timeout (negative disables
+// eviction), measured with now. onEvict, if set, is called for every evicted key.
+func NewMap[V any](timeout time.Duration, now func() time.Time, onEvict func(key string)) *Map[V] {
+	return &Map[V]{
+		timeout:   timeout,
+		now:       now,
+		onEvict:   onEvict,
+		entries:   make(map[string]*entry[V]),
+		lastSweep: now(),
+	}
+}
+
+// Get returns the value of key, creating it with create on first use, and
+// marks the key used. It also evicts idle keys, at most once per timeout so
+// Get stays O(1) amortized.
+func (m *Map[V]) Get(key string, create func() V) V {
+	now := m.now()
+	m.evict(now, false)
+
+	e, ok := m.entries[key]
+	if !ok {
+		e = &entry[V]{value: create()}
+		m.entries[key] = e
+	}
+	e.lastUsed = now
+	return e.value
+}
+
+// Touch returns the value of an existing key and marks it used.
+func (m *Map[V]) Touch(key string) (V, bool) {
+	e, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e.lastUsed = m.now()
+	return e.value, true
+}
+
+// Peek returns the value of key without marking it used.
+func (m *Map[V]) Peek(key string) (V, bool) {
+	e, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Delete removes key and reports whether it was present. onEvict is not called.
+func (m *Map[V]) Delete(key string) bool {
+	if _, ok := m.entries[key]; !ok {
+		return false
+	}
+	delete(m.entries, key)
+	return true
+}
+
+// Len returns the number of keys.
+func (m *Map[V]) Len() int {
+	return len(m.entries)
+}
+
+// Keys returns the keys, in no particular order.
+func (m *Map[V]) Keys() []string {
+	keys := make([]string, 0, len(m.entries))
+	for key := range m.entries {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// EvictIdle removes keys not used within the timeout and returns how many were removed.
+func (m *Map[V]) EvictIdle() int {
+	return m.evict(m.now(), true)
+}
+
+// evict removes idle keys. Unless force is set, it sweeps at most once per timeout.
+func (m *Map[V]) evict(now time.Time, force bool) int {
+	if m.timeout < 0 {
+		return 0
+	}
+	if !force && now.Sub(m.lastSweep) < m.timeout {
+		return 0
+	}
+	m.lastSweep = now
+
+	evicted := 0
+	for key, e := range m.entries {
+		if now.Sub(e.lastUsed) >= m.timeout {
+			delete(m.entries, key)
+			if m.onEvict != nil {
+				m.onEvict(key)
+			}
+			evicted++
+		}
+	}
+	return evicted
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMap(t *testing.T) {
+	now := time.Unix(0, 0)
+	var evicted []string
+	m := NewMap[int](time.Minute, func() time.Time { return now }, func(key string) {
+		evicted = append(evicted, key)
+	})
+
+	created := 0
+	create := func() int { created++; return created }
+	if v := m.Get("a", create); v != 1 {
+		t.Errorf("Get(a) = %d, want 1", v)
+	}
+	if v := m.Get("a", create); v != 1 {
+		t.Errorf("Get(a) again = %d, want the existing value 1", v)
+	}
+	m.Get("b", create)
+
+	now = now.Add(30 * time.Second)
+	if _, ok := m.Touch("a"); !ok {
+		t.Error("Touch(a) = false, want true")
+	}
+	if _, ok := m.Touch("missing"); ok {
+		t.Error("Touch(missing) = true, want false")
+	}
+
+	// b was last used 60s ago, a only 30s ago
+	now = now.Add(30 * time.Second)
+	m.Get("c", create)
+	if len(evicted) != 1 || evicted[0] != "b" {
+		t.Errorf("evicted = %v, want [b]", evicted)
+	}
+	if _, ok := m.Peek("a"); !ok || m.Len() != 2 {
+		t.Errorf("after sweep: Peek(a) = %v, Len() = %d, want true, 2", ok, m.Len())
+	}
+
+	if !m.Delete("a") || m.Delete("a") {
+		t.Error("Delete(a) should report true once")
+	}
+	if len(evicted) != 1 {
+		t.Errorf("Delete called onEvict: evicted = %v", evicted)
+	}
+}
+
+func TestMapEvictIdle(t *testing.T) {
+	now := time.Unix(0, 0)
+	m := NewMap[int](time.Minute, func() time.Time { return now }, nil)
+	m.Get("a", func() int { return 1 })
+
+	// Get sweeps at most once per timeout; EvictIdle always sweeps
+	now = now.Add(time.Minute)
+	if n := m.EvictIdle(); n != 1 || m.Len() != 0 {
+		t.Errorf("EvictIdle() = %d, Len() = %d, want 1, 0", n, m.Len())
+	}
+
+	disabled := NewMap[int](-1, func() time.Time { return now }, nil)
+	disabled.Get("a", func() int { return 1 })
+	now = now.Add(time.Hour)
+	if n := disabled.EvictIdle(); n != 0 || disabled.Len() != 1 {
+		t.Errorf("negative timeout: EvictIdle() = %d, Len() = %d, want 0, 1", n, disabled.Len())
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"strconv"
+
+	"github.com/kube-zen/zen-sdk/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Metrics receives the result of every Collector sweep.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveSweep records a sweep of the named collector. err is the sweep
+	// error (list failure or cancellation), if any.
+	ObserveSweep(collector string, gvr schema.GroupVersionResource, result *SweepResult, err error)
+}
+
+// PrometheusMetrics exports Collector sweeps as zen_gc_* metrics, labeled by
+// collector name and resource (GVR).
+type PrometheusMetrics struct {
+	deleted       *prometheus.CounterVec
+	failures      *prometheus.CounterVec
+	sweeps        *prometheus.CounterVec
+	sweepDuration *prometheus.HistogramVec
+	pending       *prometheus.GaugeVec
+}
+
+// NewPrometheusMetrics creates collector metrics and registers them with the
+// controller-runtime metrics registry. Collectors may share one instance.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		deleted: metrics.Register(prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_gc_resources_deleted_total",
+				Help: "Total number of expired resources deleted (dry_run=true: would have been deleted)",
+			},
+			[]string{"collector", "resource", "dry_run"},
+		)),
+		failures: metrics.Register(prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_gc_deletion_errors_total",
+				Help: "Total number of failed deletions of expired resources",
+			},
+			[]string{"collector", "resource"},
+		)),
+		sweeps: metrics.Register(prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_gc_sweeps_total",
+				Help: "Total number of GC sweeps",
+			},
+			[]string{"collector", "resource", "result"}, // "success", "error"
+		)),
+		sweepDuration: metrics.Register(prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "zen_gc_sweep_duration_seconds",
+				Help:    "Duration of GC sweeps in seconds",
+				Buckets: prometheus.ExponentialBuckets(0.01, 2, 12), // 10ms to ~40s
+			},
+			[]string{"collector", "resource"},
+		)),
+		pending: metrics.Register(prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "zen_gc_resources_pending",
+				Help: "Expired resources not deleted in the last sweep (backing off or deferred by the per-sweep limit)",
+			},
+			[]string{"collector", "resource"},
+		)),
+	}
+}
+
+// ObserveSweep implements Metrics.
+func (m *PrometheusMetrics) ObserveSweep(collector string, gvr schema.GroupVersionResource, result *SweepResult, err error) {
+	resource := gvr.GroupResource().String()
+
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.sweeps.WithLabelValues(collector, resource, outcome).Inc()
+	m.sweepDuration.WithLabelValues(collector, resource).Observe(result.Duration.Seconds())
+	m.deleted.WithLabelValues(collector, resource, strconv.FormatBool(result.DryRun)).Add(float64(result.Deleted))
+	m.failures.WithLabelValues(collector, resource).Add(float64(result.Failed))
+	m.pending.WithLabelValues(collector, resource).Set(float64(result.BackingOff + result.Deferred + result.Failed))
+}
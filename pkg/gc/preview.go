@@ -0,0 +1,231 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/gc/selector"
+	"github.com/kube-zen/zen-sdk/pkg/gc/ttl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Action is what a GC policy would do with a resource.
+type Action string
+
+const (
+	// ActionDeleteNow means the resource matches and its TTL has passed.
+	ActionDeleteNow Action = "DeleteNow"
+	// ActionDeleteLater means the resource matches and will expire at ExpiresAt.
+	ActionDeleteLater Action = "DeleteLater"
+	// ActionNever means the policy does not apply to the resource (see Reason).
+	ActionNever Action = "Never"
+)
+
+// PreviewOptions describes the GC policy to preview.
+type PreviewOptions struct {
+	// LabelSelector filters resources by label. Optional.
+	LabelSelector *metav1.LabelSelector
+
+	// Conditions filter resources by phase, labels, annotations and fields. Optional.
+	Conditions *selector.Conditions
+
+	// TTL decides when a resource expires (required).
+	TTL *ttl.Spec
+
+	// Now is the evaluation time (default: time.Now()).
+	Now time.Time
+}
+
+// PreviewEntry is the outcome of a GC policy for one resource.
+type PreviewEntry struct {
+	Namespace string     `json:"namespace,omitempty"`
+	Name      string     `json:"name"`
+	UID       types.UID  `json:"uid,omitempty"`
+	Action    Action     `json:"action"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// ETA is the time until deletion for ActionDeleteLater (e.g., "1h30m0s").
+	ETA    string `json:"eta,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Preview is the outcome of a GC policy for a set of resources.
+type Preview struct {
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Entries     []PreviewEntry `json:"entries"`
+}
+
+// PreviewResources evaluates a GC policy against resources without deleting
+// anything, reporting for each resource whether it would be deleted now,
+// later (with ETA) or never, and why.
+func PreviewResources(resources []unstructured.Unstructured, opts PreviewOptions) (*Preview, error) {
+	if opts.TTL == nil {
+		return nil, fmt.Errorf("%w: TTL spec is required", ErrInvalidConfig)
+	}
+	var sel labels.Selector
+	if opts.LabelSelector != nil {
+		var err error
+		if sel, err = metav1.LabelSelectorAsSelector(opts.LabelSelector); err != nil {
+			return nil, fmt.Errorf("%w: label selector: %v", ErrInvalidConfig, err)
+		}
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	preview := &Preview{GeneratedAt: opts.Now, Entries: make([]PreviewEntry, 0, len(resources))}
+	for i := range resources {
+		resource := &resources[i]
+		d := evaluate(resource, opts.Conditions, sel, opts.TTL, opts.Now)
+		preview.Entries = append(preview.Entries, newPreviewEntry(resource, d, opts.Now))
+	}
+	return preview, nil
+}
+
+// Preview lists the collector's resources and evaluates its policy without
+// deleting anything. Unlike DryRun, it also reports resources that will
+// expire later or never.
+func (c *Collector) Preview(ctx context.Context) (*Preview, error) {
+	now := c.config.Now()
+	preview := &Preview{GeneratedAt: now, Entries: []PreviewEntry{}}
+	err := c.list(ctx, func(resource *unstructured.Unstructured) error {
+		preview.Entries = append(preview.Entries, newPreviewEntry(resource, c.evaluate(resource, now), now))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// newPreviewEntry converts a decision to a PreviewEntry.
+func newPreviewEntry(resource *unstructured.Unstructured, d decision, now time.Time) PreviewEntry {
+	entry := PreviewEntry{
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
+		UID:       resource.GetUID(),
+		Reason:    d.reason,
+	}
+	if !d.expiresAt.IsZero() {
+		expiresAt := d.expiresAt
+		entry.ExpiresAt = &expiresAt
+	}
+
+	switch {
+	case !d.matched || (!d.expired && d.expiresAt.IsZero()):
+		entry.Action = ActionNever
+	case d.expired:
+		entry.Action = ActionDeleteNow
+		if d.expiresAt.IsZero() {
+			entry.Reason = "relative TTL expired"
+		} else {
+			entry.Reason = fmt.Sprintf("TTL expired %s ago", now.Sub(d.expiresAt).Round(time.Second))
+		}
+	default:
+		eta := d.expiresAt.Sub(now).Round(time.Second)
+		entry.Action = ActionDeleteLater
+		entry.ETA = eta.String()
+		entry.Reason = "TTL not yet expired"
+	}
+	return entry
+}
+
+// Count returns the number of entries with the given action.
+func (p *Preview) Count(action Action) int {
+	n := 0
+	for _, e := range p.Entries {
+		if e.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteTable renders the preview as an aligned text table, followed by a summary line.
+func (p *Preview) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tACTION\tEXPIRES AT\tETA\tREASON")
+	for _, e := range p.Entries {
+		expiresAt := "-"
+		if e.ExpiresAt != nil {
+			expiresAt = e.ExpiresAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			dash(e.Namespace), e.Name, e.Action, expiresAt, dash(e.ETA), e.Reason)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d to delete now, %d later, %d never\n",
+		p.Count(ActionDeleteNow), p.Count(ActionDeleteLater), p.Count(ActionNever))
+	return err
+}
+
+// WriteJSON renders the preview as indented JSON.
+func (p *Preview) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// dash returns s, or "-" if s is empty, for table cells.
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// decision is the outcome of evaluating one resource.
+type decision struct {
+	matched   bool      // resource matches the selector and conditions and is not being deleted
+	expired   bool      // TTL has passed
+	expiresAt time.Time // zero if the TTL could not be evaluated
+	reason    string    // why the resource is not collectible, if it is not
+}
+
+// evaluate decides whether a resource is collectible at now under a policy.
+// sel may be nil when the label selector was already applied by the API server.
+func evaluate(resource *unstructured.Unstructured, conditions *selector.Conditions, sel labels.Selector, spec *ttl.Spec, now time.Time) decision {
+	if resource.GetDeletionTimestamp() != nil {
+		return decision{reason: "already being deleted"}
+	}
+	if sel != nil && !sel.Matches(labels.Set(resource.GetLabels())) {
+		return decision{reason: "does not match label selector"}
+	}
+	if conditions != nil && !selector.MatchesConditionsAt(resource, conditions, now) {
+		return decision{reason: "does not match conditions"}
+	}
+
+	expiresAt, err := ttl.CalculateExpirationTimeAt(resource, spec, now)
+	switch {
+	case errors.Is(err, ttl.ErrRelativeTTLExpired):
+		return decision{matched: true, expired: true}
+	case err != nil:
+		return decision{matched: true, reason: "TTL not applicable: " + err.Error()}
+	}
+	return decision{matched: true, expired: !now.Before(expiresAt), expiresAt: expiresAt}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/gc/selector"
+	"github.com/kube-zen/zen-sdk/pkg/gc/ttl"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func previewResources() []unstructured.Unstructured {
+	deleting := newPod("deleting", 2*time.Hour, map[string]string{"app": "batch"}, "Succeeded")
+	deletedAt := metav1.NewTime(testNow)
+	deleting.SetDeletionTimestamp(&deletedAt)
+
+	return []unstructured.Unstructured{
+		*newPod("expired", 2*time.Hour, map[string]string{"app": "batch"}, "Succeeded"),
+		*newPod("young", 30*time.Minute, map[string]string{"app": "batch"}, "Succeeded"),
+		*newPod("running", 2*time.Hour, map[string]string{"app": "batch"}, "Running"),
+		*newPod("other-app", 2*time.Hour, map[string]string{"app": "web"}, "Succeeded"),
+		*deleting,
+	}
+}
+
+func previewOptions() PreviewOptions {
+	return PreviewOptions{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "batch"}},
+		Conditions:    &selector.Conditions{Phase: []string{"Succeeded"}},
+		TTL:           oneHourTTL(),
+		Now:           testNow,
+	}
+}
+
+func TestPreviewResources(t *testing.T) {
+	preview, err := PreviewResources(previewResources(), previewOptions())
+	if err != nil {
+		t.Fatalf("PreviewResources() unexpected error: %v", err)
+	}
+
+	want := map[string]struct {
+		action Action
+		eta    string
+		reason string
+	}{
+		"expired":   {action: ActionDeleteNow, reason: "TTL expired 1h0m0s ago"},
+		"young":     {action: ActionDeleteLater, eta: "30m0s", reason: "TTL not yet expired"},
+		"running":   {action: ActionNever, reason: "does not match conditions"},
+		"other-app": {action: ActionNever, reason: "does not match label selector"},
+		"deleting":  {action: ActionNever, reason: "already being deleted"},
+	}
+	if len(preview.Entries) != len(want) {
+		t.Fatalf("len(Entries) = %d, want %d", len(preview.Entries), len(want))
+	}
+	for _, e := range preview.Entries {
+		w := want[e.Name]
+		if e.Action != w.action || e.ETA != w.eta || e.Reason != w.reason {
+			t.Errorf("%s: got (%s, %q, %q), want (%s, %q, %q)", e.Name, e.Action, e.ETA, e.Reason, w.action, w.eta, w.reason)
+		}
+	}
+
+	young := preview.Entries[1]
+	if young.ExpiresAt == nil || !young.ExpiresAt.Equal(testNow.Add(30*time.Minute)) {
+		t.Errorf("young ExpiresAt = %v, want now+30m", young.ExpiresAt)
+	}
+	if preview.Count(ActionDeleteNow) != 1 || preview.Count(ActionDeleteLater) != 1 || preview.Count(ActionNever) != 3 {
+		t.Errorf("counts = %d/%d/%d, want 1/1/3", preview.Count(ActionDeleteNow), preview.Count(ActionDeleteLater), preview.Count(ActionNever))
+	}
+}
+
+func TestPreviewResources_TTLNotApplicable(t *testing.T) {
+	opts := PreviewOptions{TTL: &ttl.Spec{FieldPath: "spec.ttlSeconds"}, Now: testNow}
+	preview, err := PreviewResources(previewResources()[:1], opts)
+	if err != nil {
+		t.Fatalf("PreviewResources() unexpected error: %v", err)
+	}
+	e := preview.Entries[0]
+	if e.Action != ActionNever || !strings.HasPrefix(e.Reason, "TTL not applicable") {
+		t.Errorf("entry = %+v, want Never with TTL reason", e)
+	}
+}
+
+func TestPreviewResources_RelativeTTLAtNow(t *testing.T) {
+	// Processed 30m before testNow, which is long past in real time
+	pod := newPod("processed", 2*time.Hour, nil, "")
+	_ = unstructured.SetNestedField(pod.Object, testNow.Add(-30*time.Minute).Format(time.RFC3339), "status", "lastProcessedAt")
+	secondsAfter := int64(3600)
+	spec := &ttl.Spec{RelativeTo: "status.lastProcessedAt", SecondsAfter: &secondsAfter}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want Action
+	}{
+		{"before expiry", testNow, ActionDeleteLater},
+		{"after expiry", testNow.Add(time.Hour), ActionDeleteNow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := PreviewResources([]unstructured.Unstructured{*pod}, PreviewOptions{TTL: spec, Now: tt.now})
+			if err != nil {
+				t.Fatalf("PreviewResources() unexpected error: %v", err)
+			}
+			if e := preview.Entries[0]; e.Action != tt.want {
+				t.Errorf("entry = %+v, want %s", e, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviewResources_AgeConditionAtNow(t *testing.T) {
+	// Completed 30m before testNow, which is long past in real time
+	pod := newPod("completed", 2*time.Hour, nil, "")
+	_ = unstructured.SetNestedField(pod.Object, testNow.Add(-30*time.Minute).Format(time.RFC3339), "status", "completionTime")
+	conditions := &selector.Conditions{And: []selector.FieldCondition{{Path: "status.completionTime", Operator: "OlderThan", Value: "1h"}}}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want Action
+	}{
+		{"too recent", testNow, ActionNever},
+		{"old enough", testNow.Add(time.Hour), ActionDeleteNow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := PreviewOptions{Conditions: conditions, TTL: oneHourTTL(), Now: tt.now}
+			preview, err := PreviewResources([]unstructured.Unstructured{*pod}, opts)
+			if err != nil {
+				t.Fatalf("PreviewResources() unexpected error: %v", err)
+			}
+			if e := preview.Entries[0]; e.Action != tt.want {
+				t.Errorf("entry = %+v, want %s", e, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviewResources_InvalidOptions(t *testing.T) {
+	if _, err := PreviewResources(nil, PreviewOptions{}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("PreviewResources() without TTL error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestPreview_Render(t *testing.T) {
+	preview, err := PreviewResources(previewResources(), previewOptions())
+	if err != nil {
+		t.Fatalf("PreviewResources() unexpected error: %v", err)
+	}
+
+	var table bytes.Buffer
+	if err := preview.WriteTable(&table); err != nil {
+		t.Fatalf("WriteTable() unexpected error: %v", err)
+	}
+	out := table.String()
+	for _, want := range []string{"NAMESPACE", "ACTION", "DeleteLater", "30m0s", "1 to delete now, 1 later, 3 never"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table missing %q:\n%s", want, out)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := preview.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() unexpected error: %v", err)
+	}
+	var decoded Preview
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("WriteJSON() produced invalid JSON: %v", err)
+	}
+	if len(decoded.Entries) != 5 || decoded.Entries[0].Action != ActionDeleteNow {
+		t.Errorf("decoded = %+v", decoded)
+	}
+	if !strings.Contains(buf.String(), `"eta": "30m0s"`) {
+		t.Errorf("JSON missing eta:\n%s", buf.String())
+	}
+}
+
+func TestCollector_Preview(t *testing.T) {
+	client := newFakeClient(newPod("expired", 2*time.Hour, nil, ""), newPod("young", time.Minute, nil, ""))
+	c, err := NewCollector(client, Config{
+		Name:      "test",
+		GVR:       podsGVR,
+		Namespace: "default",
+		TTL:       oneHourTTL(),
+		Now:       func() time.Time { return testNow },
+	})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+
+	preview, err := c.Preview(context.Background())
+	if err != nil {
+		t.Fatalf("Preview() unexpected error: %v", err)
+	}
+	if preview.Count(ActionDeleteNow) != 1 || preview.Count(ActionDeleteLater) != 1 {
+		t.Errorf("Preview() = %+v, want 1 now and 1 later", preview.Entries)
+	}
+	if names := deletedNames(client); len(names) != 0 {
+		t.Errorf("Preview() deleted %v, want nothing", names)
+	}
+
+	// An empty list renders as [] like PreviewResources, not null
+	empty, err := NewCollector(newFakeClient(), Config{Name: "test", GVR: podsGVR, Namespace: "default", TTL: oneHourTTL()})
+	if err != nil {
+		t.Fatalf("NewCollector() unexpected error: %v", err)
+	}
+	preview, err = empty.Preview(context.Background())
+	if err != nil {
+		t.Fatalf("Preview() unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := preview.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"entries": []`) {
+		t.Errorf("empty preview JSON = %s, want an empty entries list", buf.String())
+	}
+}
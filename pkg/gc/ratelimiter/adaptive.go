@@ -0,0 +1,249 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Adaptive rate limiter defaults.
+const (
+	DefaultMinPerSecond     = 1
+	DefaultIncreaseStep     = 1
+	DefaultIncreaseInterval = 10 * time.Second
+	DefaultDecreaseFactor   = 0.5
+	DefaultDecreaseCooldown = time.Second
+)
+
+// defaultMaxPerSecondRatio sets MaxPerSecond to 10x the initial rate when unset.
+const defaultMaxPerSecondRatio = 10
+
+// AdaptiveConfig holds AdaptiveRateLimiter configuration.
+type AdaptiveConfig struct {
+	// InitialPerSecond is the starting rate (default: DefaultMaxPerSecond, clamped to bounds).
+	InitialPerSecond int
+
+	// MinPerSecond is the lowest rate the limiter backs off to (default: DefaultMinPerSecond).
+	MinPerSecond int
+
+	// MaxPerSecond is the highest rate the limiter recovers to
+	// (default: 10x InitialPerSecond).
+	MaxPerSecond int
+
+	// IncreaseStep is added to the rate after IncreaseInterval of healthy
+	// responses (default: DefaultIncreaseStep).
+	IncreaseStep int
+
+	// IncreaseInterval is how long responses must stay healthy before each
+	// increase (default: DefaultIncreaseInterval).
+	IncreaseInterval time.Duration
+
+	// DecreaseFactor multiplies the rate on pressure signals, between 0 and 1
+	// (default: DefaultDecreaseFactor).
+	DecreaseFactor float64
+
+	// DecreaseCooldown is the minimum time between decreases, so a burst of
+	// 429s from requests already in flight only halves the rate once
+	// (default: DefaultDecreaseCooldown).
+	DecreaseCooldown time.Duration
+
+	// LatencyThreshold treats successful calls slower than this as a pressure
+	// signal. Zero disables the latency signal.
+	LatencyThreshold time.Duration
+
+	// OnRateChange is called with the new rate whenever it changes
+	// (e.g., to update a gauge or log). Called without locks held.
+	OnRateChange func(perSecond int)
+
+	// Now is the clock that call latency, IncreaseInterval and
+	// DecreaseCooldown are measured with (default: time.Now).
+	Now func() time.Time
+}
+
+// AdaptiveRateLimiter adjusts its rate from API server feedback using AIMD
+// (additive increase, multiplicative decrease): the rate is cut by
+// DecreaseFactor when a call returns 429 TooManyRequests or is slower than
+// LatencyThreshold, and raised by IncreaseStep after each IncreaseInterval
+// of healthy calls, always within [MinPerSecond, MaxPerSecond].
+// It is safe for concurrent use by multiple goroutines.
+type AdaptiveRateLimiter struct {
+	limiter *RateLimiter
+
+	mu           sync.Mutex
+	config       AdaptiveConfig
+	rate         int
+	lastDecrease time.Time
+	healthySince time.Time
+}
+
+// NewAdaptiveRateLimiter creates a new adaptive rate limiter.
+func NewAdaptiveRateLimiter(config AdaptiveConfig) *AdaptiveRateLimiter {
+	if config.MinPerSecond <= 0 {
+		config.MinPerSecond = DefaultMinPerSecond
+	}
+	if config.InitialPerSecond <= 0 {
+		config.InitialPerSecond = DefaultMaxPerSecond
+	}
+	if config.MaxPerSecond <= 0 {
+		config.MaxPerSecond = config.InitialPerSecond * defaultMaxPerSecondRatio
+	}
+	if config.MaxPerSecond < config.MinPerSecond {
+		config.MaxPerSecond = config.MinPerSecond
+	}
+	if config.IncreaseStep <= 0 {
+		config.IncreaseStep = DefaultIncreaseStep
+	}
+	if config.IncreaseInterval <= 0 {
+		config.IncreaseInterval = DefaultIncreaseInterval
+	}
+	if config.DecreaseFactor <= 0 || config.DecreaseFactor >= 1 {
+		config.DecreaseFactor = DefaultDecreaseFactor
+	}
+	if config.DecreaseCooldown <= 0 {
+		config.DecreaseCooldown = DefaultDecreaseCooldown
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	initial := clampRate(config.InitialPerSecond, config.MinPerSecond, config.MaxPerSecond)
+	return &AdaptiveRateLimiter{
+		limiter:      NewRateLimiter(initial),
+		config:       config,
+		rate:         initial,
+		healthySince: config.Now(),
+	}
+}
+
+// Wait waits until the next operation is allowed at the current rate.
+// It returns an error if the context is canceled.
+func (a *AdaptiveRateLimiter) Wait(ctx context.Context) error {
+	return a.limiter.Wait(ctx)
+}
+
+// Allow checks if an operation is allowed at the current rate without waiting.
+func (a *AdaptiveRateLimiter) Allow() bool {
+	return a.limiter.Allow()
+}
+
+// Do waits for the rate limiter, runs fn and feeds its latency and error back
+// into the limiter. Returns the Wait error or fn's error.
+func (a *AdaptiveRateLimiter) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := a.Wait(ctx); err != nil {
+		return err
+	}
+	start := a.config.Now()
+	err := fn(ctx)
+	a.Observe(a.config.Now().Sub(start), err)
+	return err
+}
+
+// Observe feeds the outcome of one API call into the limiter.
+// 429 TooManyRequests errors and calls slower than LatencyThreshold decrease
+// the rate; successful fast calls allow it to grow again. Other errors
+// (e.g., NotFound for an already-deleted resource) say nothing about API
+// server load and are ignored.
+func (a *AdaptiveRateLimiter) Observe(latency time.Duration, err error) {
+	switch {
+	case apierrors.IsTooManyRequests(err):
+		a.decrease()
+	case err != nil:
+		return
+	case a.config.LatencyThreshold > 0 && latency > a.config.LatencyThreshold:
+		a.decrease()
+	default:
+		a.increase()
+	}
+}
+
+// decrease applies the multiplicative decrease, at most once per DecreaseCooldown.
+func (a *AdaptiveRateLimiter) decrease() {
+	a.mu.Lock()
+	now := a.config.Now()
+	// Any pressure signal restarts the healthy period
+	a.healthySince = now
+	if !a.lastDecrease.IsZero() && now.Sub(a.lastDecrease) < a.config.DecreaseCooldown {
+		a.mu.Unlock()
+		return
+	}
+	a.lastDecrease = now
+	newRate := clampRate(int(float64(a.rate)*a.config.DecreaseFactor), a.config.MinPerSecond, a.config.MaxPerSecond)
+	changed := a.setRateLocked(newRate)
+	a.mu.Unlock()
+
+	if changed {
+		a.notify(newRate)
+	}
+}
+
+// increase applies the additive increase once IncreaseInterval has passed
+// without pressure signals.
+func (a *AdaptiveRateLimiter) increase() {
+	a.mu.Lock()
+	now := a.config.Now()
+	if now.Sub(a.healthySince) < a.config.IncreaseInterval {
+		a.mu.Unlock()
+		return
+	}
+	a.healthySince = now
+	newRate := clampRate(a.rate+a.config.IncreaseStep, a.config.MinPerSecond, a.config.MaxPerSecond)
+	changed := a.setRateLocked(newRate)
+	a.mu.Unlock()
+
+	if changed {
+		a.notify(newRate)
+	}
+}
+
+// setRateLocked updates the rate and reports whether it changed. Caller must hold a.mu.
+func (a *AdaptiveRateLimiter) setRateLocked(perSecond int) bool {
+	if perSecond == a.rate {
+		return false
+	}
+	a.rate = perSecond
+	a.limiter.SetRate(perSecond)
+	return true
+}
+
+// notify calls OnRateChange, if configured.
+func (a *AdaptiveRateLimiter) notify(perSecond int) {
+	if a.config.OnRateChange != nil {
+		a.config.OnRateChange(perSecond)
+	}
+}
+
+// GetRate returns the current rate limit (operations per second).
+func (a *AdaptiveRateLimiter) GetRate() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return float64(a.rate)
+}
+
+// clampRate bounds perSecond to [minRate, maxRate].
+func clampRate(perSecond, minRate, maxRate int) int {
+	if perSecond < minRate {
+		return minRate
+	}
+	if perSecond > maxRate {
+		return maxRate
+	}
+	return perSecond
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// adaptiveClock is a manually advanced clock.
+type adaptiveClock struct {
+	now time.Time
+}
+
+func (c *adaptiveClock) Now() time.Time { return c.now }
+
+func (c *adaptiveClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestAdaptive(clock *adaptiveClock, changes *[]int) *AdaptiveRateLimiter {
+	return NewAdaptiveRateLimiter(AdaptiveConfig{
+		InitialPerSecond: 20,
+		MinPerSecond:     2,
+		MaxPerSecond:     22,
+		IncreaseStep:     1,
+		IncreaseInterval: 10 * time.Second,
+		DecreaseFactor:   0.5,
+		DecreaseCooldown: time.Second,
+		LatencyThreshold: 500 * time.Millisecond,
+		OnRateChange:     func(r int) { *changes = append(*changes, r) },
+		Now:              clock.Now,
+	})
+}
+
+func tooManyRequests() error {
+	return apierrors.NewTooManyRequests("slow down", 1)
+}
+
+func TestAdaptiveRateLimiter_DecreaseOnThrottling(t *testing.T) {
+	clock := &adaptiveClock{now: time.Unix(0, 0)}
+	var changes []int
+	a := newTestAdaptive(clock, &changes)
+
+	a.Observe(10*time.Millisecond, tooManyRequests())
+	if a.GetRate() != 10 {
+		t.Errorf("rate after 429 = %v, want 10", a.GetRate())
+	}
+
+	// Further 429s within the cooldown are from requests already in flight
+	a.Observe(10*time.Millisecond, tooManyRequests())
+	if a.GetRate() != 10 {
+		t.Errorf("rate after 429 within cooldown = %v, want 10", a.GetRate())
+	}
+
+	clock.Advance(2 * time.Second)
+	a.Observe(time.Second, nil) // slow call
+	if a.GetRate() != 5 {
+		t.Errorf("rate after slow call = %v, want 5", a.GetRate())
+	}
+
+	for i := 0; i < 5; i++ {
+		clock.Advance(2 * time.Second)
+		a.Observe(0, tooManyRequests())
+	}
+	if a.GetRate() != 2 {
+		t.Errorf("rate = %v, want MinPerSecond 2", a.GetRate())
+	}
+	if !reflect.DeepEqual(changes, []int{10, 5, 2}) {
+		t.Errorf("OnRateChange calls = %v, want [10 5 2]", changes)
+	}
+}
+
+func TestAdaptiveRateLimiter_AdditiveIncrease(t *testing.T) {
+	clock := &adaptiveClock{now: time.Unix(0, 0)}
+	var changes []int
+	a := newTestAdaptive(clock, &changes)
+
+	// Healthy calls within the first interval do not raise the rate
+	a.Observe(10*time.Millisecond, nil)
+	if a.GetRate() != 20 {
+		t.Errorf("rate = %v, want 20", a.GetRate())
+	}
+
+	for i := 0; i < 5; i++ {
+		clock.Advance(10 * time.Second)
+		a.Observe(10*time.Millisecond, nil)
+	}
+	if a.GetRate() != 22 {
+		t.Errorf("rate = %v, want MaxPerSecond 22", a.GetRate())
+	}
+
+	// A pressure signal restarts the healthy period
+	a.Observe(0, tooManyRequests())
+	clock.Advance(5 * time.Second)
+	a.Observe(0, nil)
+	if a.GetRate() != 11 {
+		t.Errorf("rate = %v, want 11 (no increase before IncreaseInterval)", a.GetRate())
+	}
+}
+
+func TestAdaptiveRateLimiter_IgnoresOtherErrors(t *testing.T) {
+	clock := &adaptiveClock{now: time.Unix(0, 0)}
+	var changes []int
+	a := newTestAdaptive(clock, &changes)
+
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "gone")
+	for i := 0; i < 3; i++ {
+		clock.Advance(20 * time.Second)
+		a.Observe(2*time.Second, notFound)
+		a.Observe(0, errors.New("connection reset"))
+	}
+	if a.GetRate() != 20 || len(changes) != 0 {
+		t.Errorf("rate = %v, changes = %v, want unchanged", a.GetRate(), changes)
+	}
+}
+
+func TestAdaptiveRateLimiter_Do(t *testing.T) {
+	a := NewAdaptiveRateLimiter(AdaptiveConfig{InitialPerSecond: 100, MinPerSecond: 1})
+
+	err := a.Do(context.Background(), func(context.Context) error { return tooManyRequests() })
+	if !apierrors.IsTooManyRequests(err) {
+		t.Errorf("Do() error = %v, want TooManyRequests", err)
+	}
+	if a.GetRate() != 50 {
+		t.Errorf("rate after Do() returned 429 = %v, want 50", a.GetRate())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	a2 := NewAdaptiveRateLimiter(AdaptiveConfig{InitialPerSecond: 1})
+	a2.Allow() // consume the only token
+	if err := a2.Do(ctx, func(context.Context) error { called = true; return nil }); err == nil || called {
+		t.Errorf("Do() with canceled context = %v, called = %v, want error and not called", err, called)
+	}
+}
+
+func TestNewAdaptiveRateLimiter_Defaults(t *testing.T) {
+	a := NewAdaptiveRateLimiter(AdaptiveConfig{})
+	if a.GetRate() != float64(DefaultMaxPerSecond) {
+		t.Errorf("rate = %v, want %d", a.GetRate(), DefaultMaxPerSecond)
+	}
+	if a.config.MaxPerSecond != DefaultMaxPerSecond*defaultMaxPerSecondRatio {
+		t.Errorf("MaxPerSecond = %d", a.config.MaxPerSecond)
+	}
+
+	// Initial rate is clamped to the bounds
+	a = NewAdaptiveRateLimiter(AdaptiveConfig{InitialPerSecond: 100, MaxPerSecond: 30})
+	if a.GetRate() != 30 {
+		t.Errorf("rate = %v, want 30", a.GetRate())
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/gc/internal/idle"
+	"golang.org/x/time/rate"
+)
+
+// DefaultIdleTimeout is how long a key may go unused before KeyedRateLimiter evicts it.
+const DefaultIdleTimeout = 10 * time.Minute
+
+// Throttle scopes reported to ThrottleMetrics.
+const (
+	// ScopeKey means the operation waited for its own key's bucket.
+	ScopeKey = "key"
+	// ScopeGlobal means the operation waited for the global ceiling.
+	ScopeGlobal = "global"
+)
+
+// ThrottleMetrics receives throttled waits from a KeyedRateLimiter.
+// Implementations must be safe for concurrent use.
+type ThrottleMetrics interface {
+	// ObserveThrottled records that an operation for key had to wait (or was
+	// rejected by Allow) because of the bucket in scope (ScopeKey or ScopeGlobal).
+	ObserveThrottled(key, scope string, wait time.Duration)
+
+	// DeleteKey removes a key that was forgotten or evicted.
+	DeleteKey(key string)
+}
+
+// KeyedConfig holds KeyedRateLimiter configuration.
+type KeyedConfig struct {
+	// PerKeyPerSecond is the rate (and burst) of each key's bucket.
+	// If <= 0, DefaultMaxPerSecond is used.
+	PerKeyPerSecond int
+
+	// GlobalPerSecond is the rate (and burst) of the bucket shared by all keys.
+	// If <= 0, there is no global ceiling.
+	GlobalPerSecond int
+
+	// IdleTimeout evicts keys not used for this long (default: DefaultIdleTimeout).
+	// Negative disables eviction.
+	IdleTimeout time.Duration
+
+	// Metrics optionally receives throttled waits (see NewPrometheusMetrics).
+	Metrics ThrottleMetrics
+
+	// Now returns the current time for idle eviction (default: time.Now).
+	Now func() time.Time
+}
+
+// KeyedRateLimiter rate limits operations per key (e.g., per GC policy or
+// client IP) under an optional global ceiling. An operation must obtain a token
+// from both its key's bucket and the global bucket, so one noisy key is limited
+// to its own rate and cannot consume the whole global budget.
+// It is safe for concurrent use by multiple goroutines.
+type KeyedRateLimiter struct {
+	mu      sync.Mutex
+	config  KeyedConfig
+	global  *rate.Limiter
+	entries *idle.Map[*rate.Limiter]
+}
+
+// NewKeyedRateLimiter creates a new keyed rate limiter.
+func NewKeyedRateLimiter(config KeyedConfig) *KeyedRateLimiter {
+	if config.PerKeyPerSecond <= 0 {
+		config.PerKeyPerSecond = DefaultMaxPerSecond
+	}
+	if config.IdleTimeout == 0 {
+		config.IdleTimeout = DefaultIdleTimeout
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	var onEvict func(string)
+	if config.Metrics != nil {
+		onEvict = config.Metrics.DeleteKey
+	}
+
+	krl := &KeyedRateLimiter{
+		config:  config,
+		entries: idle.NewMap[*rate.Limiter](config.IdleTimeout, config.Now, onEvict),
+	}
+	if config.GlobalPerSecond > 0 {
+		krl.global = rate.NewLimiter(rate.Limit(config.GlobalPerSecond), config.GlobalPerSecond)
+	}
+	return krl
+}
+
+// Wait waits until an operation for key is allowed by both the key's bucket
+// and the global ceiling. It reserves a token from both buckets at once and
+// waits for the later of the two, so a failed wait (context canceled, or its
+// deadline would be exceeded) cancels both reservations instead of losing the
+// token already taken from one bucket. As with rate.Limiter.Wait, a token whose
+// reservation time has already passed is not returned on cancellation.
+func (k *KeyedRateLimiter) Wait(ctx context.Context, key string) error {
+	now := time.Now()
+	keyReservation := k.limiterFor(key).ReserveN(now, 1)
+	if !keyReservation.OK() {
+		return fmt.Errorf("rate limiter for key %q cannot grant a token", key)
+	}
+	keyDelay := keyReservation.DelayFrom(now)
+
+	var globalReservation *rate.Reservation
+	var globalDelay time.Duration
+	if k.global != nil {
+		globalReservation = k.global.ReserveN(now, 1)
+		if !globalReservation.OK() {
+			keyReservation.CancelAt(now)
+			return fmt.Errorf("global rate limiter cannot grant a token for key %q", key)
+		}
+		globalDelay = globalReservation.DelayFrom(now)
+	}
+	cancel := func(at time.Time) {
+		keyReservation.CancelAt(at)
+		if globalReservation != nil {
+			globalReservation.CancelAt(at)
+		}
+	}
+
+	delay := max(keyDelay, globalDelay)
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		cancel(now)
+		return fmt.Errorf("rate limiter wait of %v for key %q would exceed context deadline", delay, key)
+	}
+
+	k.observeWait(key, ScopeKey, keyDelay)
+	k.observeWait(key, ScopeGlobal, globalDelay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancel(time.Now())
+		return ctx.Err()
+	}
+}
+
+// observeWait reports a wait caused by the bucket in scope, if any.
+func (k *KeyedRateLimiter) observeWait(key, scope string, wait time.Duration) {
+	if wait > 0 && k.config.Metrics != nil {
+		k.config.Metrics.ObserveThrottled(key, scope, wait)
+	}
+}
+
+// Allow checks if an operation for key is allowed now by both buckets.
+// Returns false (consuming no tokens) if either bucket is empty.
+func (k *KeyedRateLimiter) Allow(key string) bool {
+	now := time.Now()
+
+	keyReservation := k.limiterFor(key).ReserveN(now, 1)
+	if !keyReservation.OK() || keyReservation.DelayFrom(now) > 0 {
+		keyReservation.CancelAt(now)
+		k.observeRejected(key, ScopeKey)
+		return false
+	}
+	if k.global == nil {
+		return true
+	}
+
+	globalReservation := k.global.ReserveN(now, 1)
+	if !globalReservation.OK() || globalReservation.DelayFrom(now) > 0 {
+		globalReservation.CancelAt(now)
+		keyReservation.CancelAt(now)
+		k.observeRejected(key, ScopeGlobal)
+		return false
+	}
+	return true
+}
+
+// observeRejected reports an operation rejected by Allow.
+func (k *KeyedRateLimiter) observeRejected(key, scope string) {
+	if k.config.Metrics != nil {
+		k.config.Metrics.ObserveThrottled(key, scope, 0)
+	}
+}
+
+// limiterFor returns the bucket for key, creating it on first use.
+func (k *KeyedRateLimiter) limiterFor(key string) *rate.Limiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.entries.Get(key, func() *rate.Limiter {
+		return rate.NewLimiter(rate.Limit(k.config.PerKeyPerSecond), k.config.PerKeyPerSecond)
+	})
+}
+
+// Forget removes the bucket for key (e.g., when a GC policy is deleted).
+func (k *KeyedRateLimiter) Forget(key string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.entries.Delete(key) && k.config.Metrics != nil {
+		k.config.Metrics.DeleteKey(key)
+	}
+}
+
+// Len returns the number of keys with a bucket.
+func (k *KeyedRateLimiter) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.entries.Len()
+}
+
+// EvictIdle removes keys not used within IdleTimeout and returns how many were removed.
+// Buckets are also evicted periodically on use, so calling EvictIdle is optional.
+func (k *KeyedRateLimiter) EvictIdle() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.entries.EvictIdle()
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// recordingMetrics counts throttled operations per key and scope.
+type recordingMetrics struct {
+	mu        sync.Mutex
+	throttled map[string]int
+}
+
+func (m *recordingMetrics) ObserveThrottled(key, scope string, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.throttled[key+"/"+scope]++
+}
+
+func (m *recordingMetrics) DeleteKey(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k := range m.throttled {
+		if len(k) > len(key) && k[:len(key)+1] == key+"/" {
+			delete(m.throttled, k)
+		}
+	}
+}
+
+func (m *recordingMetrics) count(key, scope string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.throttled[key+"/"+scope]
+}
+
+func TestKeyedRateLimiter_PerKeyBuckets(t *testing.T) {
+	metrics := &recordingMetrics{throttled: map[string]int{}}
+	krl := NewKeyedRateLimiter(KeyedConfig{PerKeyPerSecond: 2, Metrics: metrics})
+
+	// Each key has its own burst of 2
+	for _, key := range []string{"noisy", "noisy", "quiet", "quiet"} {
+		if !krl.Allow(key) {
+			t.Errorf("Allow(%q) = false within burst", key)
+		}
+	}
+	if krl.Allow("noisy") {
+		t.Error("Allow(noisy) = true after burst exhausted")
+	}
+	if krl.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", krl.Len())
+	}
+	if metrics.count("noisy", ScopeKey) != 1 {
+		t.Errorf("throttled(noisy, key) = %d, want 1", metrics.count("noisy", ScopeKey))
+	}
+}
+
+func TestKeyedRateLimiter_GlobalCeiling(t *testing.T) {
+	metrics := &recordingMetrics{throttled: map[string]int{}}
+	krl := NewKeyedRateLimiter(KeyedConfig{PerKeyPerSecond: 10, GlobalPerSecond: 3, Metrics: metrics})
+
+	allowed := 0
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		if krl.Allow(key) {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("allowed = %d, want 3 (global ceiling)", allowed)
+	}
+	if metrics.count("d", ScopeGlobal) != 1 || metrics.count("e", ScopeGlobal) != 1 {
+		t.Errorf("expected keys d and e to be throttled by the global bucket, got %v", metrics.throttled)
+	}
+
+	// A rejection by the global bucket must not consume the key's token
+	krl2 := NewKeyedRateLimiter(KeyedConfig{PerKeyPerSecond: 1, GlobalPerSecond: 1})
+	if !krl2.Allow("a") {
+		t.Fatal("Allow(a) = false, want true")
+	}
+	if krl2.Allow("b") {
+		t.Fatal("Allow(b) = true, want false (global exhausted)")
+	}
+	time.Sleep(1100 * time.Millisecond)
+	if !krl2.Allow("b") {
+		t.Error("Allow(b) = false after global refill; key token was consumed by the rejected call")
+	}
+}
+
+func TestKeyedRateLimiter_Wait(t *testing.T) {
+	metrics := &recordingMetrics{throttled: map[string]int{}}
+	krl := NewKeyedRateLimiter(KeyedConfig{PerKeyPerSecond: 10, Metrics: metrics})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 12; i++ {
+		if err := krl.Wait(ctx, "policy"); err != nil {
+			t.Fatalf("Wait() unexpected error: %v", err)
+		}
+	}
+	// Burst of 10, then 2 more at 10/s
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("12 waits took %v, want >= 150ms", elapsed)
+	}
+	if metrics.count("policy", ScopeKey) < 1 {
+		t.Error("expected throttled waits to be reported")
+	}
+
+	// Another key is unaffected by the throttled one
+	start = time.Now()
+	if err := krl.Wait(ctx, "other"); err != nil {
+		t.Fatalf("Wait() unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Wait(other) took %v, want immediate", elapsed)
+	}
+}
+
+func TestKeyedRateLimiter_WaitContext(t *testing.T) {
+	krl := NewKeyedRateLimiter(KeyedConfig{PerKeyPerSecond: 1})
+	if err := krl.Wait(context.Background(), "a"); err != nil {
+		t.Fatalf("Wait() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := krl.Wait(ctx, "a"); err == nil {
+		t.Error("Wait() expected error when deadline is shorter than the delay")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := krl.Wait(ctx, "a"); err == nil {
+		t.Error("Wait() expected error for canceled context")
+	}
+
+	// A wait that fails on the global bucket must not consume the key's token
+	krl2 := NewKeyedRateLimiter(KeyedConfig{PerKeyPerSecond: 1, GlobalPerSecond: 1})
+	if err := krl2.Wait(context.Background(), "a"); err != nil {
+		t.Fatalf("Wait() unexpected error: %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := krl2.Wait(ctx, "b"); err == nil {
+		t.Fatal("Wait(b) expected error when the global delay exceeds the deadline")
+	}
+	if tokens := krl2.limiterFor("b").Tokens(); tokens < 1 {
+		t.Errorf("key b has %.2f tokens after the failed wait, want 1", tokens)
+	}
+}
+
+func TestKeyedRateLimiter_IdleEviction(t *testing.T) {
+	now := time.Unix(0, 0)
+	var mu sync.Mutex
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+
+	metrics := &recordingMetrics{throttled: map[string]int{}}
+	krl := NewKeyedRateLimiter(KeyedConfig{PerKeyPerSecond: 1, IdleTimeout: time.Minute, Metrics: metrics, Now: clock})
+
+	krl.Allow("idle")
+	krl.Allow("idle") // throttled
+	advance(30 * time.Second)
+	krl.Allow("active")
+	advance(40 * time.Second)
+	krl.Allow("active")
+
+	if krl.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after idle key evicted", krl.Len())
+	}
+	if metrics.count("idle", ScopeKey) != 0 {
+		t.Error("evicted key should be removed from metrics")
+	}
+
+	krl.Forget("active")
+	if krl.Len() != 0 {
+		t.Errorf("Len() = %d, want 0 after Forget", krl.Len())
+	}
+}
+
+func TestKeyedRateLimiter_ConcurrentAccess(t *testing.T) {
+	krl := NewKeyedRateLimiter(KeyedConfig{PerKeyPerSecond: 1000, GlobalPerSecond: 1000})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := []string{"a", "b", "c"}[i%3]
+			for j := 0; j < 20; j++ {
+				krl.Allow(key)
+				_ = krl.Wait(context.Background(), key)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if krl.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", krl.Len())
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	m := NewPrometheusMetrics("test-limiter")
+	other := NewPrometheusMetrics("other-limiter")
+
+	m.ObserveThrottled("policy-a", ScopeKey, 500*time.Millisecond)
+	m.ObserveThrottled("policy-b", ScopeKey, 500*time.Millisecond)
+	m.ObserveThrottled("policy-a", ScopeGlobal, 0)
+	other.ObserveThrottled("policy-a", ScopeKey, time.Second)
+
+	if got := testutil.ToFloat64(m.throttled.WithLabelValues("test-limiter", ScopeKey)); got != 2 {
+		t.Errorf("zen_ratelimiter_throttled_total = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.waited.WithLabelValues("test-limiter", ScopeKey)); got != 1 {
+		t.Errorf("zen_ratelimiter_throttled_seconds_total = %v, want 1", got)
+	}
+	if m.keyThrottled != nil {
+		t.Error("expected no per-key counters by default")
+	}
+	m.DeleteKey("policy-a") // no-op without per-key counters
+}
+
+func TestPerKeyPrometheusMetrics(t *testing.T) {
+	m := NewPerKeyPrometheusMetrics("per-key-limiter")
+	other := NewPerKeyPrometheusMetrics("other-per-key-limiter")
+
+	m.ObserveThrottled("policy-a", ScopeKey, 500*time.Millisecond)
+	m.ObserveThrottled("policy-a", ScopeGlobal, 0)
+	other.ObserveThrottled("policy-a", ScopeKey, time.Second)
+
+	if got := testutil.ToFloat64(m.keyThrottled.WithLabelValues("per-key-limiter", "policy-a", ScopeKey)); got != 1 {
+		t.Errorf("zen_ratelimiter_key_throttled_total = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.keyWaited.WithLabelValues("per-key-limiter", "policy-a", ScopeKey)); got != 0.5 {
+		t.Errorf("zen_ratelimiter_key_throttled_seconds_total = %v, want 0.5", got)
+	}
+
+	m.DeleteKey("policy-a")
+	// Only the other limiter's series remains
+	if n := testutil.CollectAndCount(m.keyThrottled); n != 1 {
+		t.Errorf("series after DeleteKey = %d, want 1", n)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetrics exports KeyedRateLimiter throttling as
+// zen_ratelimiter_throttled_total and zen_ratelimiter_throttled_seconds_total,
+// labeled by limiter name and scope ("key" or "global"). Per-key series are
+// opt-in, see NewPerKeyPrometheusMetrics.
+type PrometheusMetrics struct {
+	name      string
+	throttled *prometheus.CounterVec
+	waited    *prometheus.CounterVec
+
+	// Per-key counters, nil unless per-key metrics are enabled.
+	keyThrottled *prometheus.CounterVec
+	keyWaited    *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates throttle metrics for the named limiter and
+// registers them with the controller-runtime metrics registry.
+func NewPrometheusMetrics(name string) *PrometheusMetrics {
+	return &PrometheusMetrics{
+		name: name,
+		throttled: metrics.Register(prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_ratelimiter_throttled_total",
+				Help: "Total number of rate limited operations that had to wait or were rejected",
+			},
+			[]string{"limiter", "scope"},
+		)),
+		waited: metrics.Register(prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_ratelimiter_throttled_seconds_total",
+				Help: "Total time rate limited operations spent waiting",
+			},
+			[]string{"limiter", "scope"},
+		)),
+	}
+}
+
+// NewPerKeyPrometheusMetrics is NewPrometheusMetrics that also exports
+// zen_ratelimiter_key_throttled_total and
+// zen_ratelimiter_key_throttled_seconds_total labeled by limiter, key and
+// scope. Series are removed when a key is forgotten or evicted, but every
+// throttled key is its own series, so only use it when keys are few and bounded
+// (e.g. GC policies, not client IPs or object UIDs).
+func NewPerKeyPrometheusMetrics(name string) *PrometheusMetrics {
+	m := NewPrometheusMetrics(name)
+	m.keyThrottled = metrics.Register(prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "zen_ratelimiter_key_throttled_total",
+			Help: "Total number of rate limited operations per key that had to wait or were rejected",
+		},
+		[]string{"limiter", "key", "scope"},
+	))
+	m.keyWaited = metrics.Register(prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "zen_ratelimiter_key_throttled_seconds_total",
+			Help: "Total time rate limited operations per key spent waiting",
+		},
+		[]string{"limiter", "key", "scope"},
+	))
+	return m
+}
+
+// ObserveThrottled implements ThrottleMetrics.
+func (m *PrometheusMetrics) ObserveThrottled(key, scope string, wait time.Duration) {
+	m.throttled.WithLabelValues(m.name, scope).Inc()
+	m.waited.WithLabelValues(m.name, scope).Add(wait.Seconds())
+	if m.keyThrottled != nil {
+		m.keyThrottled.WithLabelValues(m.name, key, scope).Inc()
+		m.keyWaited.WithLabelValues(m.name, key, scope).Add(wait.Seconds())
+	}
+}
+
+// DeleteKey implements ThrottleMetrics.
+func (m *PrometheusMetrics) DeleteKey(key string) {
+	if m.keyThrottled == nil {
+		return
+	}
+	labels := prometheus.Labels{"limiter": m.name, "key": key}
+	m.keyThrottled.DeletePartialMatch(labels)
+	m.keyWaited.DeletePartialMatch(labels)
+}
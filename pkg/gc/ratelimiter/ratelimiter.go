@@ -20,6 +20,8 @@ package ratelimiter
 
 import (
 	"context"
+	"math"
+	"time"
 
 	"golang.org/x/time/rate"
 )
@@ -46,6 +48,29 @@ func NewRateLimiter(maxPerSecond int) *RateLimiter {
 	}
 }
 
+// NewRateLimiterWithBurst creates a new rate limiter with a fractional rate and
+// an independent burst, e.g. NewRateLimiterWithBurst(0.5, 5) allows one operation
+// every two seconds on average with up to 5 at once.
+// If perSecond <= 0, DefaultMaxPerSecond is used.
+// If burst <= 0, the burst is perSecond rounded up (at least 1).
+func NewRateLimiterWithBurst(perSecond float64, burst int) *RateLimiter {
+	if perSecond <= 0 {
+		perSecond = DefaultMaxPerSecond
+	}
+	if burst <= 0 {
+		burst = defaultBurst(perSecond)
+	}
+
+	return &RateLimiter{
+		limiter: rate.NewLimiter(rate.Limit(perSecond), burst),
+	}
+}
+
+// defaultBurst returns perSecond rounded up, at least 1.
+func defaultBurst(perSecond float64) int {
+	return int(math.Max(1, math.Ceil(perSecond)))
+}
+
 // Wait waits until the next operation is allowed, respecting the rate limit.
 // It returns an error if the context is canceled.
 func (rl *RateLimiter) Wait(ctx context.Context) error {
@@ -58,7 +83,37 @@ func (rl *RateLimiter) Allow() bool {
 	return rl.limiter.Allow()
 }
 
-// SetRate updates the rate limit dynamically.
+// WaitN waits until n operations are allowed, e.g. before a batch delete.
+// It returns an error if n exceeds the burst or the context is canceled.
+func (rl *RateLimiter) WaitN(ctx context.Context, n int) error {
+	return rl.limiter.WaitN(ctx, n)
+}
+
+// AllowN checks if n operations are allowed now without waiting.
+func (rl *RateLimiter) AllowN(n int) bool {
+	return rl.limiter.AllowN(time.Now(), n)
+}
+
+// Reserve reserves one operation. See ReserveN.
+func (rl *RateLimiter) Reserve() *Reservation {
+	return rl.ReserveN(1)
+}
+
+// ReserveN reserves n operations and reports how long the caller must wait
+// before performing them. Unlike WaitN it never blocks, so callers can decide
+// whether the delay is acceptable and Cancel the reservation if not.
+func (rl *RateLimiter) ReserveN(n int) *Reservation {
+	return &Reservation{r: rl.limiter.ReserveN(time.Now(), n)}
+}
+
+// Tokens returns the number of operations currently available without waiting.
+// The value may be negative while reservations are outstanding.
+func (rl *RateLimiter) Tokens() float64 {
+	return rl.limiter.Tokens()
+}
+
+// SetRate updates the rate limit dynamically and sets the burst to the same value.
+// Use SetLimit and SetBurst to change them independently.
 // If maxPerSecond <= 0, DefaultMaxPerSecond is used.
 func (rl *RateLimiter) SetRate(maxPerSecond int) {
 	if maxPerSecond <= 0 {
@@ -68,7 +123,52 @@ func (rl *RateLimiter) SetRate(maxPerSecond int) {
 	rl.limiter.SetBurst(maxPerSecond)
 }
 
+// SetLimit updates the rate limit dynamically, keeping the current burst.
+// If perSecond <= 0, DefaultMaxPerSecond is used.
+func (rl *RateLimiter) SetLimit(perSecond float64) {
+	if perSecond <= 0 {
+		perSecond = DefaultMaxPerSecond
+	}
+	rl.limiter.SetLimit(rate.Limit(perSecond))
+}
+
+// SetBurst updates the maximum number of operations allowed at once.
+// If burst <= 0, the burst is the current rate rounded up (at least 1).
+func (rl *RateLimiter) SetBurst(burst int) {
+	if burst <= 0 {
+		burst = defaultBurst(float64(rl.limiter.Limit()))
+	}
+	rl.limiter.SetBurst(burst)
+}
+
 // GetRate returns the current rate limit (operations per second).
 func (rl *RateLimiter) GetRate() float64 {
 	return float64(rl.limiter.Limit())
 }
+
+// GetBurst returns the maximum number of operations allowed at once.
+func (rl *RateLimiter) GetBurst() int {
+	return rl.limiter.Burst()
+}
+
+// Reservation holds operations reserved with ReserveN.
+type Reservation struct {
+	r *rate.Reservation
+}
+
+// OK reports whether the reservation can be satisfied (n does not exceed the burst).
+// If OK is false, Delay returns an infinite duration and nothing was reserved.
+func (r *Reservation) OK() bool {
+	return r.r.OK()
+}
+
+// Delay returns how long to wait before performing the reserved operations.
+func (r *Reservation) Delay() time.Duration {
+	return r.r.Delay()
+}
+
+// Cancel returns the reserved tokens, as far as possible, for use by other callers.
+// Call it when the reserved operations will not be performed.
+func (r *Reservation) Cancel() {
+	r.r.Cancel()
+}
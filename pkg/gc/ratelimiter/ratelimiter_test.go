@@ -142,3 +142,112 @@ func TestRateLimiter_ContextCancel(t *testing.T) {
 		t.Error("Wait() with canceled context = nil, want error")
 	}
 }
+
+func TestNewRateLimiterWithBurst(t *testing.T) {
+	tests := []struct {
+		name      string
+		perSecond float64
+		burst     int
+		wantRate  float64
+		wantBurst int
+	}{
+		{name: "fractional rate with burst", perSecond: 0.5, burst: 5, wantRate: 0.5, wantBurst: 5},
+		{name: "default burst rounds rate up", perSecond: 2.5, burst: 0, wantRate: 2.5, wantBurst: 3},
+		{name: "default burst is at least 1", perSecond: 0.1, burst: 0, wantRate: 0.1, wantBurst: 1},
+		{name: "zero rate uses default", perSecond: 0, burst: 2, wantRate: float64(DefaultMaxPerSecond), wantBurst: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := NewRateLimiterWithBurst(tt.perSecond, tt.burst)
+			if rl.GetRate() != tt.wantRate || rl.GetBurst() != tt.wantBurst {
+				t.Errorf("rate, burst = %v, %d, want %v, %d", rl.GetRate(), rl.GetBurst(), tt.wantRate, tt.wantBurst)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_BurstAndTokens(t *testing.T) {
+	rl := NewRateLimiterWithBurst(0.5, 5)
+
+	if tokens := rl.Tokens(); tokens < 4.99 {
+		t.Errorf("Tokens() = %v, want 5 (full bucket)", tokens)
+	}
+	if !rl.AllowN(5) {
+		t.Error("AllowN(5) = false, want true within burst")
+	}
+	if rl.Allow() {
+		t.Error("Allow() = true after burst exhausted at 0.5/s")
+	}
+	if tokens := rl.Tokens(); tokens > 0.1 {
+		t.Errorf("Tokens() = %v, want ~0", tokens)
+	}
+}
+
+func TestRateLimiter_SetLimitAndBurst(t *testing.T) {
+	rl := NewRateLimiter(10)
+
+	rl.SetLimit(0.5)
+	if rl.GetRate() != 0.5 || rl.GetBurst() != 10 {
+		t.Errorf("after SetLimit: rate, burst = %v, %d, want 0.5, 10", rl.GetRate(), rl.GetBurst())
+	}
+
+	rl.SetBurst(3)
+	if rl.GetBurst() != 3 {
+		t.Errorf("GetBurst() = %d, want 3", rl.GetBurst())
+	}
+
+	rl.SetBurst(0)
+	if rl.GetBurst() != 1 {
+		t.Errorf("GetBurst() after SetBurst(0) = %d, want 1", rl.GetBurst())
+	}
+
+	// SetRate keeps its original behavior: burst follows the rate
+	rl.SetRate(20)
+	if rl.GetRate() != 20 || rl.GetBurst() != 20 {
+		t.Errorf("after SetRate: rate, burst = %v, %d, want 20, 20", rl.GetRate(), rl.GetBurst())
+	}
+}
+
+func TestRateLimiter_WaitN(t *testing.T) {
+	rl := NewRateLimiterWithBurst(10, 5)
+	ctx := context.Background()
+
+	start := time.Now()
+	if err := rl.WaitN(ctx, 5); err != nil {
+		t.Fatalf("WaitN(5) unexpected error: %v", err)
+	}
+	if err := rl.WaitN(ctx, 2); err != nil {
+		t.Fatalf("WaitN(2) unexpected error: %v", err)
+	}
+	// 2 more tokens at 10/s take ~200ms
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("WaitN took %v, want >= 150ms", elapsed)
+	}
+
+	if err := rl.WaitN(ctx, 6); err == nil {
+		t.Error("WaitN(6) expected error when n exceeds burst")
+	}
+}
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	rl := NewRateLimiterWithBurst(1, 2)
+
+	r := rl.ReserveN(2)
+	if !r.OK() || r.Delay() != 0 {
+		t.Errorf("ReserveN(2) OK = %v, Delay = %v, want true, 0", r.OK(), r.Delay())
+	}
+
+	r = rl.Reserve()
+	if !r.OK() || r.Delay() < 900*time.Millisecond {
+		t.Errorf("Reserve() Delay = %v, want ~1s", r.Delay())
+	}
+	r.Cancel()
+	if tokens := rl.Tokens(); tokens < -0.1 {
+		t.Errorf("Tokens() after Cancel = %v, want ~0", tokens)
+	}
+
+	if r := rl.ReserveN(3); r.OK() {
+		t.Error("ReserveN(3) OK = true, want false when n exceeds burst")
+	}
+}
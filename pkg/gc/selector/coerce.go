@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/gc/fieldpath"
+	"github.com/kube-zen/zen-sdk/pkg/gc/ttl"
+)
+
+// compareNumber evaluates Gt/Gte/Lt/Lte between a field value and the condition value.
+func compareNumber(fieldValue interface{}, operator, conditionValue string) bool {
+	actual, ok := fieldpath.ToFloat64(fieldValue)
+	if !ok {
+		return false
+	}
+	expected, err := strconv.ParseFloat(strings.TrimSpace(conditionValue), 64)
+	if err != nil {
+		return false
+	}
+
+	switch operator {
+	case OperatorGt:
+		return actual > expected
+	case OperatorGte:
+		return actual >= expected
+	case OperatorLt:
+		return actual < expected
+	case OperatorLte:
+		return actual <= expected
+	default:
+		return false
+	}
+}
+
+// compareTimestamp evaluates OlderThan/NewerThan for an RFC3339 timestamp field.
+// The condition value is either a duration relative to now ("1h", "7d")
+// or an absolute RFC3339 timestamp.
+func compareTimestamp(fieldValue interface{}, operator, conditionValue string, now time.Time) bool {
+	str, ok := fieldValue.(string)
+	if !ok {
+		return false
+	}
+	timestamp, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return false
+	}
+
+	threshold, err := time.Parse(time.RFC3339, conditionValue)
+	if err != nil {
+		age, err := ttl.ParseDuration(conditionValue)
+		if err != nil {
+			return false
+		}
+		threshold = now.Add(-age)
+	}
+
+	switch operator {
+	case OperatorOlderThan:
+		return timestamp.Before(threshold)
+	case OperatorNewerThan:
+		return timestamp.After(threshold)
+	default:
+		return false
+	}
+}
+
+// listContains reports whether a list field contains a scalar element equal to any of values.
+func listContains(fieldValue interface{}, values []string) bool {
+	list, ok := fieldValue.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, item := range list {
+		if str, ok := fieldpath.ScalarString(item); ok && containsString(values, str) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LabelConditionsFromSelector converts a Kubernetes label selector into label conditions,
+// so GC policies can reuse standard selectors.
+// MatchLabels entries become Equals conditions (sorted by key); MatchExpressions map to
+// In, NotIn, Exists and DoesNotExist. A nil selector returns no conditions (matches everything).
+func LabelConditionsFromSelector(ls *metav1.LabelSelector) ([]LabelCondition, error) {
+	if ls == nil {
+		return nil, nil
+	}
+
+	conditions := make([]LabelCondition, 0, len(ls.MatchLabels)+len(ls.MatchExpressions))
+
+	keys := make([]string, 0, len(ls.MatchLabels))
+	for key := range ls.MatchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		conditions = append(conditions, LabelCondition{
+			Key:      key,
+			Value:    ls.MatchLabels[key],
+			Operator: OperatorEquals,
+		})
+	}
+
+	for _, expr := range ls.MatchExpressions {
+		cond := LabelCondition{Key: expr.Key}
+		switch expr.Operator {
+		case metav1.LabelSelectorOpIn, metav1.LabelSelectorOpNotIn:
+			if len(expr.Values) == 0 {
+				return nil, fmt.Errorf("label selector operator %q for key %q requires values", expr.Operator, expr.Key)
+			}
+			cond.Operator = string(expr.Operator)
+			cond.Values = append([]string(nil), expr.Values...)
+		case metav1.LabelSelectorOpExists, metav1.LabelSelectorOpDoesNotExist:
+			if len(expr.Values) > 0 {
+				return nil, fmt.Errorf("label selector operator %q for key %q must not have values", expr.Operator, expr.Key)
+			}
+			cond.Operator = string(expr.Operator)
+		default:
+			return nil, fmt.Errorf("unsupported label selector operator %q for key %q", expr.Operator, expr.Key)
+		}
+		conditions = append(conditions, cond)
+	}
+
+	return conditions, nil
+}
+
+// ToLabelSelector converts label conditions into a Kubernetes label selector.
+// Equals conditions become MatchLabels entries; the other operators become MatchExpressions.
+// Gt and Lt have no metav1.LabelSelector equivalent and return an error.
+func ToLabelSelector(conditions []LabelCondition) (*metav1.LabelSelector, error) {
+	ls := &metav1.LabelSelector{}
+
+	for _, cond := range conditions {
+		switch cond.Operator {
+		case OperatorEquals:
+			if existing, ok := ls.MatchLabels[cond.Key]; ok && existing != cond.Value {
+				// Conflicting equality on the same key can never match; keep both
+				// constraints instead of silently overwriting one.
+				ls.MatchExpressions = append(ls.MatchExpressions, metav1.LabelSelectorRequirement{
+					Key:      cond.Key,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{cond.Value},
+				})
+				continue
+			}
+			if ls.MatchLabels == nil {
+				ls.MatchLabels = make(map[string]string)
+			}
+			ls.MatchLabels[cond.Key] = cond.Value
+		case OperatorIn, OperatorNotIn:
+			values := cond.values()
+			ls.MatchExpressions = append(ls.MatchExpressions, metav1.LabelSelectorRequirement{
+				Key:      cond.Key,
+				Operator: metav1.LabelSelectorOperator(cond.Operator),
+				Values:   append([]string(nil), values...),
+			})
+		case OperatorExists, "":
+			ls.MatchExpressions = append(ls.MatchExpressions, metav1.LabelSelectorRequirement{
+				Key:      cond.Key,
+				Operator: metav1.LabelSelectorOpExists,
+			})
+		case OperatorDoesNotExist:
+			ls.MatchExpressions = append(ls.MatchExpressions, metav1.LabelSelectorRequirement{
+				Key:      cond.Key,
+				Operator: metav1.LabelSelectorOpDoesNotExist,
+			})
+		default:
+			return nil, fmt.Errorf("label condition operator %q for key %q has no label selector equivalent", cond.Operator, cond.Key)
+		}
+	}
+
+	return ls, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestLabelConditionsFromSelector(t *testing.T) {
+	ls := &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "myapp", "env": "prod"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"web", "api"}},
+			{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist},
+		},
+	}
+
+	conditions, err := LabelConditionsFromSelector(ls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []LabelCondition{
+		{Key: "app", Value: "myapp", Operator: "Equals"},
+		{Key: "env", Value: "prod", Operator: "Equals"},
+		{Key: "tier", Values: []string{"web", "api"}, Operator: "In"},
+		{Key: "canary", Operator: "DoesNotExist"},
+	}
+	if !reflect.DeepEqual(conditions, want) {
+		t.Errorf("LabelConditionsFromSelector() = %+v, want %+v", conditions, want)
+	}
+}
+
+func TestLabelConditionsFromSelector_Invalid(t *testing.T) {
+	tests := []*metav1.LabelSelector{
+		{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: metav1.LabelSelectorOpIn}}},
+		{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: metav1.LabelSelectorOpExists, Values: []string{"x"}}}},
+		{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "a", Operator: "Gt", Values: []string{"1"}}}},
+	}
+
+	for _, ls := range tests {
+		if _, err := LabelConditionsFromSelector(ls); err == nil {
+			t.Errorf("LabelConditionsFromSelector(%+v) expected error", ls)
+		}
+	}
+}
+
+func TestLabelConditionsFromSelector_Nil(t *testing.T) {
+	conditions, err := LabelConditionsFromSelector(nil)
+	if err != nil || conditions != nil {
+		t.Errorf("LabelConditionsFromSelector(nil) = %v, %v, want nil, nil", conditions, err)
+	}
+}
+
+func TestToLabelSelector_RoundTrip(t *testing.T) {
+	conditions := []LabelCondition{
+		{Key: "app", Value: "myapp", Operator: "Equals"},
+		{Key: "env", Values: []string{"prod", "staging"}, Operator: "In"},
+		{Key: "tier", Value: "batch", Operator: "NotIn"},
+		{Key: "owner", Operator: "Exists"},
+		{Key: "canary", Operator: "DoesNotExist"},
+	}
+
+	ls, err := ToLabelSelector(conditions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		t.Fatalf("generated selector is invalid: %v", err)
+	}
+
+	cases := []map[string]string{
+		{"app": "myapp", "env": "prod", "owner": "team-a"},
+		{"app": "myapp", "env": "prod", "owner": "team-a", "tier": "batch"},
+		{"app": "myapp", "env": "dev", "owner": "team-a"},
+		{"app": "myapp", "env": "staging", "owner": "team-a", "canary": "true"},
+		{"app": "myapp", "env": "staging"},
+	}
+	for _, lbls := range cases {
+		resource := &unstructured.Unstructured{}
+		resource.SetLabels(lbls)
+		want := sel.Matches(labels.Set(lbls))
+		if got := MatchesLabels(resource, conditions); got != want {
+			t.Errorf("labels %v: MatchesLabels = %v, selector = %v", lbls, got, want)
+		}
+	}
+
+	back, err := LabelConditionsFromSelector(ls)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(back) != len(conditions) {
+		t.Errorf("round trip returned %d conditions, want %d", len(back), len(conditions))
+	}
+}
+
+func TestToLabelSelector_Unsupported(t *testing.T) {
+	tests := [][]LabelCondition{
+		{{Key: "priority", Value: "5", Operator: "Gt"}},
+		{{Key: "env", Operator: "Matches"}},
+	}
+
+	for _, conditions := range tests {
+		if _, err := ToLabelSelector(conditions); err == nil {
+			t.Errorf("ToLabelSelector(%+v) expected error", conditions)
+		}
+	}
+}
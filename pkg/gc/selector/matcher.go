@@ -19,15 +19,48 @@ limitations under the License.
 package selector
 
 import (
+	"strconv"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/gc/fieldpath"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
+// Condition operators.
+const (
+	OperatorExists       = "Exists"
+	OperatorDoesNotExist = "DoesNotExist"
+	OperatorEquals       = "Equals"
+	OperatorIn           = "In"
+	OperatorNotIn        = "NotIn"
+	OperatorGt           = "Gt"
+	OperatorLt           = "Lt"
+
+	// Field-only operators.
+	OperatorNotEquals = "NotEquals"
+	OperatorGte       = "Gte"
+	OperatorLte       = "Lte"
+	OperatorOlderThan = "OlderThan"
+	OperatorNewerThan = "NewerThan"
+	OperatorContains  = "Contains"
+)
+
 // LabelCondition defines a label matching condition.
 type LabelCondition struct {
 	Key      string
-	Value    string
-	Operator string // Exists, Equals, In, NotIn
+	Value    string   // Single value (for Equals, and In/NotIn/Gt/Lt when Values is empty)
+	Values   []string // Multiple values (for In/NotIn; single integer for Gt/Lt)
+	Operator string   // Exists, DoesNotExist, Equals, In, NotIn, Gt, Lt
+}
+
+// values returns the condition's value set, falling back to the single Value.
+// An empty Value is kept, so In with only Value: "" matches an empty label value.
+func (c LabelCondition) values() []string {
+	if len(c.Values) > 0 {
+		return c.Values
+	}
+	return []string{c.Value}
 }
 
 // AnnotationCondition defines an annotation matching condition.
@@ -37,11 +70,32 @@ type AnnotationCondition struct {
 }
 
 // FieldCondition defines a field matching condition.
+// Operators:
+//   - Exists, DoesNotExist: field presence (any type)
+//   - Equals, NotEquals, In, NotIn: compare the field's string form (strings, numbers, bools);
+//     In and NotIn test membership of Values together with Value
+//   - Gt, Gte, Lt, Lte: numeric comparison (ints, floats, numeric strings)
+//   - OlderThan, NewerThan: RFC3339 timestamp field compared against a duration
+//     ("1h", "7d") relative to now, or an absolute RFC3339 timestamp
+//   - Contains: list field contains an element equal to Value (or any of Values)
 type FieldCondition struct {
-	Path     string   // Dot-separated field path (e.g., "status.phase")
-	Operator string   // Equals, In, NotIn
+	Path     string   // Field path (e.g., "status.phase", `status.conditions[?type=="Ready"].status`)
+	Operator string   // See operators above; defaults to Equals
 	Value    string   // Single value
-	Values   []string // Multiple values (for In/NotIn)
+	Values   []string // Multiple values (for In/NotIn/Contains)
+}
+
+// inValues reports whether s equals Value or any of Values (the In/NotIn set).
+func (c FieldCondition) inValues(s string) bool {
+	return s == c.Value || containsString(c.Values, s)
+}
+
+// values returns the condition's value set, falling back to the single Value.
+func (c FieldCondition) values() []string {
+	if len(c.Values) > 0 {
+		return c.Values
+	}
+	return []string{c.Value}
 }
 
 // Conditions defines a set of matching conditions.
@@ -54,25 +108,36 @@ type Conditions struct {
 
 // MatchesLabels checks if a resource matches label conditions.
 // All conditions must match (AND logic).
+// Set-based operators follow Kubernetes label selector semantics:
+// NotIn and DoesNotExist match when the label is absent, and Gt/Lt
+// require the label value to be an integer.
 func MatchesLabels(resource *unstructured.Unstructured, conditions []LabelCondition) bool {
 	resourceLabels := resource.GetLabels()
 	for _, cond := range conditions {
 		value, exists := resourceLabels[cond.Key]
 		switch cond.Operator {
-		case "Exists", "":
+		case OperatorExists, "":
 			if !exists {
 				return false
 			}
-		case "Equals":
-			if !exists || value != cond.Value {
+		case OperatorDoesNotExist:
+			if exists {
 				return false
 			}
-		case "In":
+		case OperatorEquals:
 			if !exists || value != cond.Value {
 				return false
 			}
-		case "NotIn":
-			if exists && value == cond.Value {
+		case OperatorIn:
+			if !exists || !containsString(cond.values(), value) {
+				return false
+			}
+		case OperatorNotIn:
+			if exists && containsString(cond.values(), value) {
+				return false
+			}
+		case OperatorGt, OperatorLt:
+			if !exists || !compareIntegerLabel(value, cond) {
 				return false
 			}
 		default:
@@ -83,6 +148,37 @@ func MatchesLabels(resource *unstructured.Unstructured, conditions []LabelCondit
 	return true
 }
 
+// compareIntegerLabel evaluates a Gt/Lt condition against a label value.
+// Returns false if either side is not an integer.
+func compareIntegerLabel(value string, cond LabelCondition) bool {
+	values := cond.values()
+	if len(values) != 1 {
+		return false
+	}
+	actual, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false
+	}
+	expected, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return false
+	}
+	if cond.Operator == OperatorGt {
+		return actual > expected
+	}
+	return actual < expected
+}
+
+// containsString reports whether values contains s.
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 // MatchesAnnotations checks if a resource matches annotation conditions.
 // All conditions must match (AND logic).
 func MatchesAnnotations(resource *unstructured.Unstructured, conditions []AnnotationCondition) bool {
@@ -115,35 +211,52 @@ func MatchesPhase(resource *unstructured.Unstructured, phases []string) bool {
 }
 
 // MatchesField checks if a resource field matches a field condition.
+// Only DoesNotExist matches a missing field; every other operator requires the field
+// to be present and of a type the operator can coerce, and fails safe otherwise.
 func MatchesField(resource *unstructured.Unstructured, condition FieldCondition) bool {
-	// Parse field path
-	fieldPath := parseFieldPath(condition.Path)
-	fieldValue, found, err := unstructured.NestedString(resource.Object, fieldPath...)
-	if err != nil || !found {
+	return MatchesFieldAt(resource, condition, time.Now())
+}
+
+// MatchesFieldAt is MatchesField with OlderThan and NewerThan measured from now.
+func MatchesFieldAt(resource *unstructured.Unstructured, condition FieldCondition, now time.Time) bool {
+	path, err := fieldpath.Compile(condition.Path)
+	if err != nil {
+		return false
+	}
+	fieldValue, found, err := path.Get(resource.Object)
+	if err != nil {
 		return false
 	}
 
 	switch condition.Operator {
-	case "Equals", "":
-		return fieldValue == condition.Value
-	case "In":
-		// Check if value is in Values list
-		for _, v := range condition.Values {
-			if fieldValue == v {
-				return true
-			}
-		}
-		// Fallback to single Value if Values is empty
-		return fieldValue == condition.Value
-	case "NotIn":
-		// Check if value is NOT in Values list
-		for _, v := range condition.Values {
-			if fieldValue == v {
-				return false
-			}
-		}
-		// Fallback to single Value if Values is empty
-		return fieldValue != condition.Value
+	case OperatorExists:
+		return found
+	case OperatorDoesNotExist:
+		return !found
+	}
+	if !found {
+		return false
+	}
+
+	switch condition.Operator {
+	case OperatorEquals, "":
+		str, ok := fieldpath.ScalarString(fieldValue)
+		return ok && str == condition.Value
+	case OperatorNotEquals:
+		str, ok := fieldpath.ScalarString(fieldValue)
+		return ok && str != condition.Value
+	case OperatorIn:
+		str, ok := fieldpath.ScalarString(fieldValue)
+		return ok && condition.inValues(str)
+	case OperatorNotIn:
+		str, ok := fieldpath.ScalarString(fieldValue)
+		return ok && !condition.inValues(str)
+	case OperatorGt, OperatorGte, OperatorLt, OperatorLte:
+		return compareNumber(fieldValue, condition.Operator, condition.Value)
+	case OperatorOlderThan, OperatorNewerThan:
+		return compareTimestamp(fieldValue, condition.Operator, condition.Value, now)
+	case OperatorContains:
+		return listContains(fieldValue, condition.values())
 	default:
 		return false
 	}
@@ -151,8 +264,13 @@ func MatchesField(resource *unstructured.Unstructured, condition FieldCondition)
 
 // MatchesFields checks if a resource matches all field conditions (AND logic).
 func MatchesFields(resource *unstructured.Unstructured, conditions []FieldCondition) bool {
+	return MatchesFieldsAt(resource, conditions, time.Now())
+}
+
+// MatchesFieldsAt is MatchesFields with OlderThan and NewerThan measured from now.
+func MatchesFieldsAt(resource *unstructured.Unstructured, conditions []FieldCondition, now time.Time) bool {
 	for _, cond := range conditions {
-		if !MatchesField(resource, cond) {
+		if !MatchesFieldAt(resource, cond, now) {
 			return false
 		}
 	}
@@ -161,6 +279,12 @@ func MatchesFields(resource *unstructured.Unstructured, conditions []FieldCondit
 
 // MatchesConditions checks if a resource matches all conditions (AND logic).
 func MatchesConditions(resource *unstructured.Unstructured, conditions *Conditions) bool {
+	return MatchesConditionsAt(resource, conditions, time.Now())
+}
+
+// MatchesConditionsAt is MatchesConditions with OlderThan and NewerThan field
+// conditions measured from now, e.g. to preview a policy at another time.
+func MatchesConditionsAt(resource *unstructured.Unstructured, conditions *Conditions, now time.Time) bool {
 	if conditions == nil {
 		return true
 	}
@@ -174,7 +298,7 @@ func MatchesConditions(resource *unstructured.Unstructured, conditions *Conditio
 	if !MatchesAnnotations(resource, conditions.HasAnnotations) {
 		return false
 	}
-	if !MatchesFields(resource, conditions.And) {
+	if !MatchesFieldsAt(resource, conditions.And, now) {
 		return false
 	}
 	return true
@@ -194,27 +318,3 @@ func MatchesLabelSelector(resource *unstructured.Unstructured, selectorStr strin
 	resourceLabels := labels.Set(resource.GetLabels())
 	return selector.Matches(resourceLabels), nil
 }
-
-// parseFieldPath parses a dot-separated field path into a slice.
-func parseFieldPath(path string) []string {
-	if path == "" {
-		return nil
-	}
-	// Simple split - can be enhanced for array indexing if needed
-	result := []string{}
-	current := ""
-	for _, ch := range path {
-		if ch == '.' {
-			if current != "" {
-				result = append(result, current)
-				current = ""
-			}
-		} else {
-			current += string(ch)
-		}
-	}
-	if current != "" {
-		result = append(result, current)
-	}
-	return result
-}
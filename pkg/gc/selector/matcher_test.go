@@ -18,6 +18,7 @@ package selector
 
 import (
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -54,6 +55,49 @@ func TestMatchesLabels_NotMatch(t *testing.T) {
 	}
 }
 
+func TestMatchesLabels_SetOperators(t *testing.T) {
+	resource := &unstructured.Unstructured{}
+	resource.SetLabels(map[string]string{
+		"app":      "myapp",
+		"env":      "prod",
+		"priority": "7",
+		"empty":    "",
+	})
+
+	tests := []struct {
+		name      string
+		condition LabelCondition
+		want      bool
+	}{
+		{name: "In values match", condition: LabelCondition{Key: "env", Operator: "In", Values: []string{"staging", "prod"}}, want: true},
+		{name: "In values no match", condition: LabelCondition{Key: "env", Operator: "In", Values: []string{"dev", "staging"}}, want: false},
+		{name: "In missing label", condition: LabelCondition{Key: "tier", Operator: "In", Values: []string{"web"}}, want: false},
+		{name: "In single Value fallback", condition: LabelCondition{Key: "env", Operator: "In", Value: "prod"}, want: true},
+		{name: "In empty Value matches empty label", condition: LabelCondition{Key: "empty", Operator: "In", Value: ""}, want: true},
+		{name: "In empty Value no match", condition: LabelCondition{Key: "env", Operator: "In", Value: ""}, want: false},
+		{name: "NotIn empty Value", condition: LabelCondition{Key: "empty", Operator: "NotIn"}, want: false},
+		{name: "NotIn values match", condition: LabelCondition{Key: "env", Operator: "NotIn", Values: []string{"dev", "staging"}}, want: true},
+		{name: "NotIn values no match", condition: LabelCondition{Key: "env", Operator: "NotIn", Values: []string{"prod"}}, want: false},
+		{name: "NotIn missing label", condition: LabelCondition{Key: "tier", Operator: "NotIn", Values: []string{"web"}}, want: true},
+		{name: "DoesNotExist missing", condition: LabelCondition{Key: "tier", Operator: "DoesNotExist"}, want: true},
+		{name: "DoesNotExist present", condition: LabelCondition{Key: "app", Operator: "DoesNotExist"}, want: false},
+		{name: "Gt true", condition: LabelCondition{Key: "priority", Operator: "Gt", Values: []string{"5"}}, want: true},
+		{name: "Gt false", condition: LabelCondition{Key: "priority", Operator: "Gt", Value: "7"}, want: false},
+		{name: "Lt true", condition: LabelCondition{Key: "priority", Operator: "Lt", Value: "10"}, want: true},
+		{name: "Lt non-integer label", condition: LabelCondition{Key: "env", Operator: "Lt", Value: "10"}, want: false},
+		{name: "Gt missing label", condition: LabelCondition{Key: "tier", Operator: "Gt", Value: "1"}, want: false},
+		{name: "unknown operator", condition: LabelCondition{Key: "app", Operator: "Matches", Value: "myapp"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesLabels(resource, []LabelCondition{tt.condition}); got != tt.want {
+				t.Errorf("MatchesLabels(%+v) = %v, want %v", tt.condition, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMatchesAnnotations(t *testing.T) {
 	resource := &unstructured.Unstructured{}
 	resource.SetAnnotations(map[string]string{
@@ -161,6 +205,124 @@ func TestMatchesField_NotIn(t *testing.T) {
 	}
 }
 
+func TestMatchesConditionsAt(t *testing.T) {
+	completed := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"completionTime": completed.Format(time.RFC3339),
+			},
+		},
+	}
+	conditions := &Conditions{And: []FieldCondition{{Path: "status.completionTime", Operator: "OlderThan", Value: "1h"}}}
+
+	if MatchesConditionsAt(resource, conditions, completed.Add(30*time.Minute)) {
+		t.Error("OlderThan 1h matched 30m after completion")
+	}
+	if !MatchesConditionsAt(resource, conditions, completed.Add(2*time.Hour)) {
+		t.Error("OlderThan 1h did not match 2h after completion")
+	}
+}
+
+func TestMatchesField_TypedOperators(t *testing.T) {
+	now := time.Now()
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"finalizers": []interface{}{"kubernetes.io/pvc-protection", "example.com/cleanup"},
+			},
+			"spec": map[string]interface{}{
+				"replicas":  int64(3),
+				"threshold": 0.75,
+				"suspended": true,
+				"priority":  "7",
+				"severity":  "critical",
+			},
+			"status": map[string]interface{}{
+				"phase":          "Failed",
+				"restartCount":   int64(6),
+				"completionTime": now.Add(-2 * time.Hour).Format(time.RFC3339),
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		condition FieldCondition
+		want      bool
+	}{
+		{name: "Exists present", condition: FieldCondition{Path: "spec.replicas", Operator: "Exists"}, want: true},
+		{name: "Exists missing", condition: FieldCondition{Path: "spec.missing", Operator: "Exists"}, want: false},
+		{name: "DoesNotExist missing", condition: FieldCondition{Path: "spec.missing", Operator: "DoesNotExist"}, want: true},
+		{name: "DoesNotExist present", condition: FieldCondition{Path: "status.phase", Operator: "DoesNotExist"}, want: false},
+		{name: "Equals int", condition: FieldCondition{Path: "spec.replicas", Operator: "Equals", Value: "3"}, want: true},
+		{name: "Equals bool", condition: FieldCondition{Path: "spec.suspended", Operator: "Equals", Value: "true"}, want: true},
+		{name: "Equals float", condition: FieldCondition{Path: "spec.threshold", Value: "0.75"}, want: true},
+		{name: "NotEquals", condition: FieldCondition{Path: "status.phase", Operator: "NotEquals", Value: "Running"}, want: true},
+		{name: "NotEquals same", condition: FieldCondition{Path: "status.phase", Operator: "NotEquals", Value: "Failed"}, want: false},
+		{name: "NotEquals missing", condition: FieldCondition{Path: "spec.missing", Operator: "NotEquals", Value: "x"}, want: false},
+		{name: "In int", condition: FieldCondition{Path: "spec.replicas", Operator: "In", Values: []string{"1", "3"}}, want: true},
+		{name: "In Value with Values", condition: FieldCondition{Path: "status.phase", Operator: "In", Value: "Failed", Values: []string{"Succeeded"}}, want: true},
+		{name: "NotIn Value with Values", condition: FieldCondition{Path: "status.phase", Operator: "NotIn", Value: "Failed", Values: []string{"Succeeded"}}, want: false},
+		{name: "NotIn neither", condition: FieldCondition{Path: "status.phase", Operator: "NotIn", Value: "Pending", Values: []string{"Succeeded"}}, want: true},
+		{name: "Gt int", condition: FieldCondition{Path: "status.restartCount", Operator: "Gt", Value: "5"}, want: true},
+		{name: "Gt equal", condition: FieldCondition{Path: "status.restartCount", Operator: "Gt", Value: "6"}, want: false},
+		{name: "Gte equal", condition: FieldCondition{Path: "status.restartCount", Operator: "Gte", Value: "6"}, want: true},
+		{name: "Lt float", condition: FieldCondition{Path: "spec.threshold", Operator: "Lt", Value: "0.8"}, want: true},
+		{name: "Lte numeric string", condition: FieldCondition{Path: "spec.priority", Operator: "Lte", Value: "7"}, want: true},
+		{name: "Gt non-numeric field", condition: FieldCondition{Path: "spec.severity", Operator: "Gt", Value: "1"}, want: false},
+		{name: "Gt non-numeric value", condition: FieldCondition{Path: "spec.replicas", Operator: "Gt", Value: "many"}, want: false},
+		{name: "OlderThan duration", condition: FieldCondition{Path: "status.completionTime", Operator: "OlderThan", Value: "1h"}, want: true},
+		{name: "OlderThan days", condition: FieldCondition{Path: "status.completionTime", Operator: "OlderThan", Value: "1d"}, want: false},
+		{name: "NewerThan duration", condition: FieldCondition{Path: "status.completionTime", Operator: "NewerThan", Value: "3h"}, want: true},
+		{name: "OlderThan absolute", condition: FieldCondition{Path: "status.completionTime", Operator: "OlderThan", Value: now.Format(time.RFC3339)}, want: true},
+		{name: "OlderThan non-timestamp", condition: FieldCondition{Path: "status.phase", Operator: "OlderThan", Value: "1h"}, want: false},
+		{name: "Contains", condition: FieldCondition{Path: "metadata.finalizers", Operator: "Contains", Value: "example.com/cleanup"}, want: true},
+		{name: "Contains any of Values", condition: FieldCondition{Path: "metadata.finalizers", Operator: "Contains", Values: []string{"a", "kubernetes.io/pvc-protection"}}, want: true},
+		{name: "Contains no match", condition: FieldCondition{Path: "metadata.finalizers", Operator: "Contains", Value: "other"}, want: false},
+		{name: "Contains non-list", condition: FieldCondition{Path: "status.phase", Operator: "Contains", Value: "Failed"}, want: false},
+		{name: "Equals on list", condition: FieldCondition{Path: "metadata.finalizers", Operator: "Equals", Value: "x"}, want: false},
+		{name: "unknown operator", condition: FieldCondition{Path: "status.phase", Operator: "Matches", Value: "Failed"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesField(resource, tt.condition); got != tt.want {
+				t.Errorf("MatchesField(%+v) = %v, want %v", tt.condition, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesField_IndexedAndFilteredPaths(t *testing.T) {
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"phase": "Failed",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False"},
+				},
+				"containerStatuses": []interface{}{
+					map[string]interface{}{"name": "app", "restartCount": int64(6)},
+				},
+			},
+		},
+	}
+
+	conditions := []FieldCondition{
+		{Path: "status.phase", Operator: "Equals", Value: "Failed"},
+		{Path: "status.containerStatuses[0].restartCount", Operator: "Gt", Value: "5"},
+		{Path: `status.conditions[?type=="Ready"].status`, Operator: "Equals", Value: "False"},
+	}
+	if !MatchesFields(resource, conditions) {
+		t.Error("expected indexed and filtered field conditions to match")
+	}
+
+	if MatchesField(resource, FieldCondition{Path: "status..phase", Operator: "Exists"}) {
+		t.Error("expected invalid path to not match")
+	}
+}
+
 func TestMatchesConditions(t *testing.T) {
 	resource := &unstructured.Unstructured{
 		Object: map[string]interface{}{
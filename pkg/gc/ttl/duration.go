@@ -0,0 +1,115 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ttl
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDuration indicates a TTL duration string could not be parsed.
+var ErrInvalidDuration = errors.New("invalid TTL duration")
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// ParseDuration parses a human-readable TTL duration.
+//
+// Accepted formats:
+//   - Go duration strings: "90s", "36h", "1h30m"
+//   - Extended units for days and weeks: "7d", "2w", "1w3d12h", "1.5d"
+//   - Plain integers, interpreted as seconds: "3600"
+//
+// Negative durations are rejected. Errors wrap ErrInvalidDuration.
+func ParseDuration(s string) (time.Duration, error) {
+	value := strings.TrimSpace(s)
+	if value == "" {
+		return 0, fmt.Errorf("%w: empty string", ErrInvalidDuration)
+	}
+
+	// Plain integers are seconds, matching the int64 TTL fields
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("%w: %q is negative", ErrInvalidDuration, s)
+		}
+		if seconds > math.MaxInt64/int64(time.Second) {
+			return 0, fmt.Errorf("%w: %q overflows", ErrInvalidDuration, s)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	if strings.HasPrefix(value, "-") {
+		return 0, fmt.Errorf("%w: %q is negative", ErrInvalidDuration, s)
+	}
+	value = strings.TrimPrefix(value, "+")
+
+	var total time.Duration
+	rest := value
+	for rest != "" {
+		// Split the next "<number><unit>" component
+		i := 0
+		for i < len(rest) && (rest[i] == '.' || (rest[i] >= '0' && rest[i] <= '9')) {
+			i++
+		}
+		j := i
+		for j < len(rest) && (rest[j] < '0' || rest[j] > '9') && rest[j] != '.' {
+			j++
+		}
+		number, unit := rest[:i], rest[i:j]
+		if number == "" || unit == "" {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+		}
+		rest = rest[j:]
+
+		var component time.Duration
+		switch unit {
+		case "d", "w":
+			n, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+			}
+			scale := day
+			if unit == "w" {
+				scale = week
+			}
+			f := n * float64(scale)
+			if f > math.MaxInt64 {
+				return 0, fmt.Errorf("%w: %q overflows", ErrInvalidDuration, s)
+			}
+			component = time.Duration(f)
+		default:
+			d, err := time.ParseDuration(number + unit)
+			if err != nil {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidDuration, s)
+			}
+			component = d
+		}
+
+		if total > math.MaxInt64-component {
+			return 0, fmt.Errorf("%w: %q overflows", ErrInvalidDuration, s)
+		}
+		total += component
+	}
+
+	return total, nil
+}
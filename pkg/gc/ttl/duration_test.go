@@ -0,0 +1,66 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ttl
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  time.Duration
+	}{
+		{name: "go duration", input: "36h", want: 36 * time.Hour},
+		{name: "compound go duration", input: "1h30m", want: 90 * time.Minute},
+		{name: "days", input: "7d", want: 7 * 24 * time.Hour},
+		{name: "weeks", input: "2w", want: 14 * 24 * time.Hour},
+		{name: "fractional days", input: "1.5d", want: 36 * time.Hour},
+		{name: "mixed units", input: "1w2d3h", want: (9*24 + 3) * time.Hour},
+		{name: "plain seconds", input: "3600", want: time.Hour},
+		{name: "surrounding whitespace", input: " 30m ", want: 30 * time.Minute},
+		{name: "zero", input: "0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDuration_Invalid(t *testing.T) {
+	inputs := []string{"", "abc", "7 days", "7x", "d", "-1h", "-60", "1d-2h", "1..5d", "99999999999w"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseDuration(input)
+			if !errors.Is(err, ErrInvalidDuration) {
+				t.Errorf("ParseDuration(%q) error = %v, want ErrInvalidDuration", input, err)
+			}
+		})
+	}
+}
@@ -19,9 +19,10 @@ package ttl
 import (
 	"errors"
 	"fmt"
-	"strings"
+	"strconv"
 	"time"
 
+	"github.com/kube-zen/zen-sdk/pkg/gc/fieldpath"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -51,78 +52,158 @@ var (
 //
 // Supported TTL modes:
 // 1. Fixed TTL: SecondsAfterCreation (e.g., 3600 = 1 hour after creation)
-// 2. Dynamic TTL: FieldPath pointing to int64 field (e.g., spec.ttlSeconds)
-// 3. Mapped TTL: FieldPath pointing to string field + Mappings (e.g., severity -> TTL)
+// 2. Dynamic TTL: FieldPath pointing to int64 field (e.g., spec.ttlSeconds) or duration string (e.g., "7d")
+// 3. Mapped TTL: FieldPath pointing to string field + Mappings/DurationMappings (e.g., severity -> TTL)
 // 4. Relative TTL: RelativeTo timestamp field + SecondsAfter (e.g., 2 hours after last processed)
 func CalculateExpirationTime(resource *unstructured.Unstructured, spec *Spec) (time.Time, error) {
+	return CalculateExpirationTimeAt(resource, spec, time.Now())
+}
+
+// CalculateExpirationTimeAt is CalculateExpirationTime evaluated at now instead
+// of the current time, which only matters for relative TTLs: it returns
+// ErrRelativeTTLExpired if the relative TTL has passed by now.
+func CalculateExpirationTimeAt(resource *unstructured.Unstructured, spec *Spec, now time.Time) (time.Time, error) {
+	e := evaluate(resource, spec, now)
+	if e.Err != nil {
+		return time.Time{}, e.Err
+	}
+	return e.ExpiresAt, nil
+}
+
+// evaluate applies the TTL spec to a resource at now and records how the result
+// was reached. It is shared by CalculateExpirationTime and Explain so both always agree.
+func evaluate(resource *unstructured.Unstructured, spec *Spec, now time.Time) *Explanation {
+	e := &Explanation{}
 	if spec == nil {
-		return time.Time{}, ErrNoValidTTLConfiguration
+		e.Err = ErrNoValidTTLConfiguration
+		return e
 	}
 
 	// Option 1: Fixed TTL (seconds after creation)
 	if spec.SecondsAfterCreation != nil {
-		creationTime := resource.GetCreationTimestamp().Time
-		return creationTime.Add(time.Duration(*spec.SecondsAfterCreation) * time.Second), nil
+		e.Mode = ModeFixed
+		e.TTL = time.Duration(*spec.SecondsAfterCreation) * time.Second
+		e.ExpiresAt = resource.GetCreationTimestamp().Time.Add(e.TTL)
+		return e
 	}
 
 	// Option 2: Dynamic TTL from field
 	if spec.FieldPath != "" {
-		fieldPath := parseFieldPath(spec.FieldPath)
+		e.Mode = ModeDynamic
+		e.FieldPath = spec.FieldPath
+		path, err := fieldpath.Compile(spec.FieldPath)
+		if err != nil {
+			e.Err = err
+			return e
+		}
+		creationTime := resource.GetCreationTimestamp().Time
 
 		// Try to get as int64 first
-		value, found, err := unstructured.NestedInt64(resource.Object, fieldPath...)
+		value, found, err := path.GetInt64(resource)
 		if err == nil && found {
-			creationTime := resource.GetCreationTimestamp().Time
-			return creationTime.Add(time.Duration(value) * time.Second), nil
+			e.FieldFound = true
+			e.FieldValue = strconv.FormatInt(value, 10)
+			e.TTL = time.Duration(value) * time.Second
+			e.ExpiresAt = creationTime.Add(e.TTL)
+			return e
 		}
 
-		// Try as string for mappings
-		strValue, found, err := unstructured.NestedString(resource.Object, fieldPath...)
+		// Try as string for mappings or duration strings
+		strValue, found, err := path.GetString(resource)
 		if err == nil && found {
+			e.FieldFound = true
+			e.FieldValue = strValue
+
 			// Option 3: Mapped TTL
-			if len(spec.Mappings) > 0 {
-				var ttl int64
-				if mappedTTL, ok := spec.Mappings[strValue]; ok {
-					ttl = mappedTTL
-				} else if spec.Default != nil {
-					ttl = *spec.Default
+			if len(spec.Mappings) > 0 || len(spec.DurationMappings) > 0 {
+				e.Mode = ModeMapped
+				ttl, ok, err := mappedTTL(spec, strValue)
+				if err != nil {
+					e.Err = err
+					return e
+				}
+				if ok {
+					e.MappingKey = strValue
 				} else {
-					return time.Time{}, fmt.Errorf("%w: %s", ErrNoMappingForFieldValue, strValue)
+					ttl, ok, err = defaultTTL(spec)
+					if err != nil {
+						e.Err = err
+						return e
+					}
+					if !ok {
+						e.Err = fmt.Errorf("%w: %s", ErrNoMappingForFieldValue, strValue)
+						return e
+					}
+					e.UsedDefault = true
 				}
-				creationTime := resource.GetCreationTimestamp().Time
-				return creationTime.Add(time.Duration(ttl) * time.Second), nil
+				e.TTL = ttl
+				e.ExpiresAt = creationTime.Add(ttl)
+				return e
 			}
+
+			// Duration string in the field itself (e.g., "7d", "36h")
+			ttl, err := ParseDuration(strValue)
+			if err != nil {
+				e.Err = fmt.Errorf("%s: %w", spec.FieldPath, err)
+				return e
+			}
+			e.TTL = ttl
+			e.ExpiresAt = creationTime.Add(ttl)
+			return e
 		}
 
-		if !found && spec.Default != nil {
-			creationTime := resource.GetCreationTimestamp().Time
-			return creationTime.Add(time.Duration(*spec.Default) * time.Second), nil
+		if !found {
+			ttl, ok, err := defaultTTL(spec)
+			if err != nil {
+				e.Err = err
+				return e
+			}
+			if ok {
+				e.UsedDefault = true
+				e.TTL = ttl
+				e.ExpiresAt = creationTime.Add(ttl)
+				return e
+			}
 		}
-		return time.Time{}, fmt.Errorf("%w: %s", ErrFieldPathNotFound, spec.FieldPath)
+		e.FieldFound = found
+		e.Err = fmt.Errorf("%w: %s", ErrFieldPathNotFound, spec.FieldPath)
+		return e
 	}
 
 	// Option 4: Relative TTL (relative to another timestamp field)
 	if spec.RelativeTo != "" && spec.SecondsAfter != nil {
-		fieldPath := parseFieldPath(spec.RelativeTo)
-		timestampStr, found, err := unstructured.NestedString(resource.Object, fieldPath...)
+		e.Mode = ModeRelative
+		e.FieldPath = spec.RelativeTo
+		path, err := fieldpath.Compile(spec.RelativeTo)
+		if err != nil {
+			e.Err = err
+			return e
+		}
+		timestampStr, found, err := path.GetString(resource)
 		if err != nil || !found {
-			return time.Time{}, fmt.Errorf("%w: %s", ErrRelativeTimestampFieldNotFound, spec.RelativeTo)
+			e.Err = fmt.Errorf("%w: %s", ErrRelativeTimestampFieldNotFound, spec.RelativeTo)
+			return e
 		}
+		e.FieldFound = true
+		e.FieldValue = timestampStr
 
 		timestamp, err := time.Parse(time.RFC3339, timestampStr)
 		if err != nil {
-			return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidTimestampFormat, err)
+			e.Err = fmt.Errorf("%w: %w", ErrInvalidTimestampFormat, err)
+			return e
 		}
 
 		// Calculate absolute expiration time from the relative timestamp
-		expirationTime := timestamp.Add(time.Duration(*spec.SecondsAfter) * time.Second)
-		if time.Now().After(expirationTime) {
-			return time.Time{}, fmt.Errorf("%w", ErrRelativeTTLExpired)
+		e.TTL = time.Duration(*spec.SecondsAfter) * time.Second
+		e.ExpiresAt = timestamp.Add(e.TTL)
+		if expiredAt(e.ExpiresAt, now) {
+			e.Err = fmt.Errorf("%w", ErrRelativeTTLExpired)
 		}
-		return expirationTime, nil
+		return e
 	}
 
-	return time.Time{}, fmt.Errorf("%w", ErrNoValidTTLConfiguration)
+	e.Err = fmt.Errorf("%w", ErrNoValidTTLConfiguration)
+	return e
 }
 
 // IsExpired checks if a resource has expired based on TTL spec.
@@ -137,15 +218,43 @@ func IsExpired(resource *unstructured.Unstructured, spec *Spec) (bool, error) {
 		return false, nil
 	}
 
-	return time.Now().After(expirationTime), nil
+	return expiredAt(expirationTime, time.Now()), nil
+}
+
+// expiredAt reports whether a resource expiring at expiresAt is expired at now.
+// A resource is not yet expired at the exact expiry instant.
+func expiredAt(expiresAt, now time.Time) bool {
+	return now.After(expiresAt)
+}
+
+// mappedTTL looks up the TTL for a field value in Mappings, then DurationMappings.
+// Returns false if neither contains the value.
+func mappedTTL(spec *Spec, value string) (time.Duration, bool, error) {
+	if seconds, ok := spec.Mappings[value]; ok {
+		return time.Duration(seconds) * time.Second, true, nil
+	}
+	if durationStr, ok := spec.DurationMappings[value]; ok {
+		ttl, err := ParseDuration(durationStr)
+		if err != nil {
+			return 0, false, fmt.Errorf("mapping %q: %w", value, err)
+		}
+		return ttl, true, nil
+	}
+	return 0, false, nil
 }
 
-// parseFieldPath parses a dot-separated field path into a slice for nested field access.
-// Example: "spec.severity" -> ["spec", "severity"]
-// Example: "status.lastProcessedAt" -> ["status", "lastProcessedAt"]
-func parseFieldPath(path string) []string {
-	if path == "" {
-		return nil
+// defaultTTL returns the fallback TTL from Default, then DefaultDuration.
+// Returns false if neither is set.
+func defaultTTL(spec *Spec) (time.Duration, bool, error) {
+	if spec.Default != nil {
+		return time.Duration(*spec.Default) * time.Second, true, nil
+	}
+	if spec.DefaultDuration != "" {
+		ttl, err := ParseDuration(spec.DefaultDuration)
+		if err != nil {
+			return 0, false, fmt.Errorf("default: %w", err)
+		}
+		return ttl, true, nil
 	}
-	return strings.Split(path, ".")
+	return 0, false, nil
 }
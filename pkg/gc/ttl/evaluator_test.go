@@ -17,6 +17,7 @@ limitations under the License.
 package ttl
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -182,6 +183,31 @@ func TestCalculateExpirationTime_RelativeTTL_Expired(t *testing.T) {
 	}
 }
 
+func TestCalculateExpirationTimeAt_RelativeTTL(t *testing.T) {
+	lastProcessed := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"lastProcessedAt": lastProcessed.Format(time.RFC3339),
+			},
+		},
+	}
+	secondsAfter := int64(3600)
+	spec := &Spec{RelativeTo: "status.lastProcessedAt", SecondsAfter: &secondsAfter}
+
+	expirationTime, err := CalculateExpirationTimeAt(resource, spec, lastProcessed.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !expirationTime.Equal(lastProcessed.Add(time.Hour)) {
+		t.Errorf("expected %v, got %v", lastProcessed.Add(time.Hour), expirationTime)
+	}
+
+	if _, err := CalculateExpirationTimeAt(resource, spec, lastProcessed.Add(2*time.Hour)); !errors.Is(err, ErrRelativeTTLExpired) {
+		t.Errorf("expected ErrRelativeTTLExpired, got %v", err)
+	}
+}
+
 func TestIsExpired_NotExpired(t *testing.T) {
 	creationTime := time.Now().Add(-30 * time.Minute)
 	resource := &unstructured.Unstructured{}
@@ -249,3 +275,164 @@ func TestCalculateExpirationTime_FieldPathNotFound(t *testing.T) {
 		t.Error("expected error for field path not found")
 	}
 }
+
+func TestCalculateExpirationTime_DurationStringField(t *testing.T) {
+	creationTime := time.Now().Add(-1 * time.Hour)
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"ttl": "7d",
+			},
+		},
+	}
+	resource.SetCreationTimestamp(metav1.Time{Time: creationTime})
+
+	spec := &Spec{
+		FieldPath: "spec.ttl",
+	}
+
+	expirationTime, err := CalculateExpirationTime(resource, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := creationTime.Add(7 * 24 * time.Hour)
+	if !expirationTime.Truncate(time.Second).Equal(expected.Truncate(time.Second)) {
+		t.Errorf("expected %v, got %v", expected, expirationTime)
+	}
+}
+
+func TestCalculateExpirationTime_InvalidDurationStringField(t *testing.T) {
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"ttl": "soon",
+			},
+		},
+	}
+
+	spec := &Spec{
+		FieldPath: "spec.ttl",
+	}
+
+	_, err := CalculateExpirationTime(resource, spec)
+	if !errors.Is(err, ErrInvalidDuration) {
+		t.Errorf("expected ErrInvalidDuration, got %v", err)
+	}
+}
+
+func TestCalculateExpirationTime_DurationMappings(t *testing.T) {
+	creationTime := time.Now().Add(-2 * time.Hour)
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"severity": "critical",
+			},
+		},
+	}
+	resource.SetCreationTimestamp(metav1.Time{Time: creationTime})
+
+	spec := &Spec{
+		FieldPath: "spec.severity",
+		Mappings: map[string]int64{
+			"normal": 3600,
+		},
+		DurationMappings: map[string]string{
+			"critical": "30d",
+		},
+	}
+
+	expirationTime, err := CalculateExpirationTime(resource, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := creationTime.Add(30 * 24 * time.Hour)
+	if !expirationTime.Truncate(time.Second).Equal(expected.Truncate(time.Second)) {
+		t.Errorf("expected %v, got %v", expected, expirationTime)
+	}
+}
+
+func TestCalculateExpirationTime_DefaultDuration(t *testing.T) {
+	creationTime := time.Now().Add(-30 * time.Minute)
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"severity": "unknown",
+			},
+		},
+	}
+	resource.SetCreationTimestamp(metav1.Time{Time: creationTime})
+
+	spec := &Spec{
+		FieldPath: "spec.severity",
+		DurationMappings: map[string]string{
+			"critical": "30d",
+		},
+		DefaultDuration: "36h",
+	}
+
+	expirationTime, err := CalculateExpirationTime(resource, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := creationTime.Add(36 * time.Hour)
+	if !expirationTime.Truncate(time.Second).Equal(expected.Truncate(time.Second)) {
+		t.Errorf("expected %v, got %v", expected, expirationTime)
+	}
+
+	// Missing field also falls back to DefaultDuration
+	missing := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	missing.SetCreationTimestamp(metav1.Time{Time: creationTime})
+	expirationTime, err = CalculateExpirationTime(missing, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !expirationTime.Truncate(time.Second).Equal(expected.Truncate(time.Second)) {
+		t.Errorf("expected %v, got %v", expected, expirationTime)
+	}
+}
+
+func TestCalculateExpirationTime_InvalidMappedDuration(t *testing.T) {
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"severity": "critical",
+			},
+		},
+	}
+
+	spec := &Spec{
+		FieldPath: "spec.severity",
+		DurationMappings: map[string]string{
+			"critical": "30 days",
+		},
+	}
+
+	_, err := CalculateExpirationTime(resource, spec)
+	if !errors.Is(err, ErrInvalidDuration) {
+		t.Errorf("expected ErrInvalidDuration, got %v", err)
+	}
+}
+
+func TestCalculateExpirationTime_AnnotationWithDots(t *testing.T) {
+	creationTime := time.Now().Add(-1 * time.Hour)
+	resource := &unstructured.Unstructured{}
+	resource.SetCreationTimestamp(metav1.Time{Time: creationTime})
+	resource.SetAnnotations(map[string]string{"gc.kube-zen.io/ttl": "36h"})
+
+	spec := &Spec{
+		FieldPath: `metadata.annotations["gc.kube-zen.io/ttl"]`,
+	}
+
+	expirationTime, err := CalculateExpirationTime(resource, spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := creationTime.Add(36 * time.Hour)
+	if !expirationTime.Truncate(time.Second).Equal(expected.Truncate(time.Second)) {
+		t.Errorf("expected %v, got %v", expected, expirationTime)
+	}
+}
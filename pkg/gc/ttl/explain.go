@@ -0,0 +1,149 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ttl
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Mode identifies which TTL mode was applied to a resource.
+type Mode string
+
+const (
+	// ModeNone means no TTL mode applied (nil or empty spec).
+	ModeNone Mode = ""
+
+	// ModeFixed is SecondsAfterCreation.
+	ModeFixed Mode = "Fixed"
+
+	// ModeDynamic is FieldPath holding a TTL in seconds or a duration string.
+	ModeDynamic Mode = "Dynamic"
+
+	// ModeMapped is FieldPath with Mappings/DurationMappings.
+	ModeMapped Mode = "Mapped"
+
+	// ModeRelative is RelativeTo with SecondsAfter.
+	ModeRelative Mode = "Relative"
+)
+
+// Explanation describes how a TTL decision was reached for a resource.
+// It answers "why was (or wasn't) this resource collected?".
+type Explanation struct {
+	// Mode is the TTL mode that applied.
+	Mode Mode
+
+	// FieldPath is the field that was read (FieldPath or RelativeTo), if any.
+	FieldPath string
+
+	// FieldFound is true if FieldPath was present in the resource.
+	FieldFound bool
+
+	// FieldValue is the value read from FieldPath, formatted as a string.
+	FieldValue string
+
+	// MappingKey is the mapping entry that matched (Mapped mode only).
+	MappingKey string
+
+	// UsedDefault is true if Default/DefaultDuration was used.
+	UsedDefault bool
+
+	// TTL is the computed time-to-live.
+	TTL time.Duration
+
+	// ExpiresAt is the computed expiration time (zero if it could not be calculated).
+	ExpiresAt time.Time
+
+	// Remaining is the time left until ExpiresAt (negative once expired).
+	Remaining time.Duration
+
+	// Expired is true if the resource should be deleted now.
+	Expired bool
+
+	// Err is the evaluation error, if any. It wraps one of the package's sentinel
+	// errors (including ErrInvalidDuration), or fieldpath.ErrInvalidPath if
+	// FieldPath or RelativeTo is not a valid field path.
+	// An already-expired relative TTL is reported via Expired, not Err.
+	Err error
+}
+
+// Explain evaluates the TTL spec against a resource and reports how the decision was reached:
+// which mode applied, which field and value were read, which mapping matched or whether
+// the default was used, the computed expiry and the remaining time.
+//
+// Explain uses the same evaluation as CalculateExpirationTime and IsExpired.
+func Explain(resource *unstructured.Unstructured, spec *Spec) *Explanation {
+	return explainAt(resource, spec, time.Now())
+}
+
+// explainAt is Explain evaluated at now.
+func explainAt(resource *unstructured.Unstructured, spec *Spec, now time.Time) *Explanation {
+	e := evaluate(resource, spec, now)
+
+	if errors.Is(e.Err, ErrRelativeTTLExpired) {
+		e.Err = nil
+	}
+	if e.Err != nil || e.ExpiresAt.IsZero() {
+		return e
+	}
+
+	e.Remaining = e.ExpiresAt.Sub(now)
+	e.Expired = expiredAt(e.ExpiresAt, now)
+	return e
+}
+
+// String returns a one-line human-readable summary, suitable for events and status messages.
+func (e *Explanation) String() string {
+	var b strings.Builder
+
+	if e.Mode == ModeNone {
+		b.WriteString("no TTL mode applied")
+	} else {
+		fmt.Fprintf(&b, "%s TTL", strings.ToLower(string(e.Mode)))
+	}
+
+	if e.FieldPath != "" {
+		if e.FieldFound {
+			fmt.Fprintf(&b, ", %s=%q", e.FieldPath, e.FieldValue)
+		} else {
+			fmt.Fprintf(&b, ", %s not found", e.FieldPath)
+		}
+	}
+	if e.MappingKey != "" {
+		fmt.Fprintf(&b, ", mapping %q", e.MappingKey)
+	}
+	if e.UsedDefault {
+		b.WriteString(", default used")
+	}
+
+	if e.Err != nil {
+		fmt.Fprintf(&b, ": %v", e.Err)
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, ", ttl %s, expires at %s", e.TTL, e.ExpiresAt.UTC().Format(time.RFC3339))
+	if e.Expired {
+		fmt.Fprintf(&b, " (expired %s ago)", (-e.Remaining).Round(time.Second))
+	} else {
+		fmt.Fprintf(&b, " (in %s)", e.Remaining.Round(time.Second))
+	}
+	return b.String()
+}
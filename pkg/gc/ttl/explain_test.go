@@ -0,0 +1,189 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ttl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestExplain_Fixed(t *testing.T) {
+	creationTime := time.Now().Add(-2 * time.Hour)
+	resource := &unstructured.Unstructured{}
+	resource.SetCreationTimestamp(metav1.Time{Time: creationTime})
+
+	ttlSeconds := int64(3600)
+	e := Explain(resource, &Spec{SecondsAfterCreation: &ttlSeconds})
+
+	if e.Err != nil {
+		t.Fatalf("unexpected error: %v", e.Err)
+	}
+	if e.Mode != ModeFixed {
+		t.Errorf("Mode = %q, want %q", e.Mode, ModeFixed)
+	}
+	if e.TTL != time.Hour {
+		t.Errorf("TTL = %v, want 1h", e.TTL)
+	}
+	if !e.Expired || e.Remaining > 0 {
+		t.Errorf("Expired = %v, Remaining = %v, want expired", e.Expired, e.Remaining)
+	}
+	if !strings.Contains(e.String(), "expired") {
+		t.Errorf("String() = %q, want it to mention expiry", e.String())
+	}
+}
+
+func TestExplain_ExpiryBoundary(t *testing.T) {
+	creationTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	resource := &unstructured.Unstructured{}
+	resource.SetCreationTimestamp(metav1.Time{Time: creationTime})
+	ttlSeconds := int64(3600)
+	spec := &Spec{SecondsAfterCreation: &ttlSeconds}
+	expiresAt := creationTime.Add(time.Hour)
+
+	// Same comparison as IsExpired: not expired at the exact expiry instant
+	if e := explainAt(resource, spec, expiresAt); e.Expired || e.Remaining != 0 {
+		t.Errorf("at expiry: Expired = %v, Remaining = %v, want not expired", e.Expired, e.Remaining)
+	}
+	if expiredAt(expiresAt, expiresAt) {
+		t.Error("expiredAt() = true at the expiry instant, want false")
+	}
+	if e := explainAt(resource, spec, expiresAt.Add(time.Nanosecond)); !e.Expired {
+		t.Error("just after expiry: Expired = false, want true")
+	}
+}
+
+func TestExplain_MappedWithMatch(t *testing.T) {
+	creationTime := time.Now().Add(-30 * time.Minute)
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"severity": "critical",
+			},
+		},
+	}
+	resource.SetCreationTimestamp(metav1.Time{Time: creationTime})
+
+	e := Explain(resource, &Spec{
+		FieldPath: "spec.severity",
+		Mappings:  map[string]int64{"critical": 86400},
+	})
+
+	if e.Err != nil {
+		t.Fatalf("unexpected error: %v", e.Err)
+	}
+	if e.Mode != ModeMapped {
+		t.Errorf("Mode = %q, want %q", e.Mode, ModeMapped)
+	}
+	if e.FieldPath != "spec.severity" || !e.FieldFound || e.FieldValue != "critical" {
+		t.Errorf("field = %q/%v/%q, want spec.severity/true/critical", e.FieldPath, e.FieldFound, e.FieldValue)
+	}
+	if e.MappingKey != "critical" || e.UsedDefault {
+		t.Errorf("MappingKey = %q, UsedDefault = %v", e.MappingKey, e.UsedDefault)
+	}
+	if e.Expired {
+		t.Error("Expired = true, want false")
+	}
+	if e.Remaining < 23*time.Hour || e.Remaining > 24*time.Hour {
+		t.Errorf("Remaining = %v, want ~23h30m", e.Remaining)
+	}
+}
+
+func TestExplain_DefaultUsed(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	resource.SetCreationTimestamp(metav1.Time{Time: time.Now()})
+
+	defaultTTL := int64(60)
+	e := Explain(resource, &Spec{
+		FieldPath: "spec.ttlSeconds",
+		Default:   &defaultTTL,
+	})
+
+	if e.Err != nil {
+		t.Fatalf("unexpected error: %v", e.Err)
+	}
+	if e.Mode != ModeDynamic || e.FieldFound || !e.UsedDefault {
+		t.Errorf("Mode = %q, FieldFound = %v, UsedDefault = %v", e.Mode, e.FieldFound, e.UsedDefault)
+	}
+	if !strings.Contains(e.String(), "not found") || !strings.Contains(e.String(), "default used") {
+		t.Errorf("String() = %q", e.String())
+	}
+}
+
+func TestExplain_NoMapping(t *testing.T) {
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"severity": "unknown",
+			},
+		},
+	}
+
+	e := Explain(resource, &Spec{
+		FieldPath: "spec.severity",
+		Mappings:  map[string]int64{"critical": 86400},
+	})
+
+	if !errors.Is(e.Err, ErrNoMappingForFieldValue) {
+		t.Errorf("Err = %v, want ErrNoMappingForFieldValue", e.Err)
+	}
+	if e.Expired || !e.ExpiresAt.IsZero() {
+		t.Errorf("Expired = %v, ExpiresAt = %v, want not expired and zero", e.Expired, e.ExpiresAt)
+	}
+	if e.FieldValue != "unknown" {
+		t.Errorf("FieldValue = %q, want unknown", e.FieldValue)
+	}
+}
+
+func TestExplain_RelativeExpired(t *testing.T) {
+	lastProcessed := time.Now().Add(-2 * time.Hour)
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"lastProcessedAt": lastProcessed.Format(time.RFC3339),
+			},
+		},
+	}
+
+	secondsAfter := int64(3600)
+	e := Explain(resource, &Spec{RelativeTo: "status.lastProcessedAt", SecondsAfter: &secondsAfter})
+
+	// An already-expired relative TTL is an expiry, not an error
+	if e.Err != nil {
+		t.Fatalf("unexpected error: %v", e.Err)
+	}
+	if e.Mode != ModeRelative || !e.Expired {
+		t.Errorf("Mode = %q, Expired = %v, want Relative/true", e.Mode, e.Expired)
+	}
+	if e.ExpiresAt.IsZero() {
+		t.Error("ExpiresAt should be set for an expired relative TTL")
+	}
+}
+
+func TestExplain_NilSpec(t *testing.T) {
+	e := Explain(&unstructured.Unstructured{}, nil)
+	if !errors.Is(e.Err, ErrNoValidTTLConfiguration) || e.Mode != ModeNone {
+		t.Errorf("Mode = %q, Err = %v", e.Mode, e.Err)
+	}
+	if !strings.HasPrefix(e.String(), "no TTL mode applied") {
+		t.Errorf("String() = %q", e.String())
+	}
+}
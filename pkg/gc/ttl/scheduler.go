@@ -0,0 +1,308 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ttl
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultSchedulerBufferSize is the default capacity of the expired channel.
+const DefaultSchedulerBufferSize = 100
+
+// ScheduledResource identifies a resource tracked by the Scheduler.
+type ScheduledResource struct {
+	UID       types.UID
+	Namespace string
+	Name      string
+	ExpiresAt time.Time
+}
+
+// NamespacedName returns the namespace/name of the scheduled resource.
+func (r ScheduledResource) NamespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: r.Namespace, Name: r.Name}
+}
+
+// SchedulerConfig holds scheduler configuration.
+type SchedulerConfig struct {
+	// BufferSize is the capacity of the Expired() channel.
+	// If <= 0, DefaultSchedulerBufferSize is used.
+	BufferSize int
+
+	// Now returns the current time. Defaults to time.Now (override in tests).
+	Now func() time.Time
+}
+
+// Scheduler tracks expiration times for many resources in a min-heap,
+// so controllers can requeue at the exact expiry instead of polling IsExpired.
+// It is safe for concurrent use by multiple goroutines.
+type Scheduler struct {
+	mu      sync.Mutex
+	items   expiryHeap
+	index   map[types.UID]*scheduledItem
+	now     func() time.Time
+	expired chan ScheduledResource
+	wake    chan struct{}
+
+	// generation is bumped on every ScheduleAt, so Run can tell whether the
+	// entry it delivered is still the one that was peeked.
+	generation uint64
+}
+
+// NewScheduler creates a new scheduler with the given configuration.
+func NewScheduler(config SchedulerConfig) *Scheduler {
+	if config.BufferSize <= 0 {
+		config.BufferSize = DefaultSchedulerBufferSize
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return &Scheduler{
+		index:   make(map[types.UID]*scheduledItem),
+		now:     config.Now,
+		expired: make(chan ScheduledResource, config.BufferSize),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Schedule computes the expiration time of a resource and tracks it by UID.
+// Scheduling a UID that is already tracked updates its expiration time,
+// so it can be called on every add/update event.
+//
+// Resources whose relative TTL has already passed are scheduled to expire immediately.
+// If the expiration time cannot be calculated, the resource is no longer tracked
+// and the error from CalculateExpirationTime is returned.
+func (s *Scheduler) Schedule(resource *unstructured.Unstructured, spec *Spec) (time.Time, error) {
+	now := s.now()
+	expiresAt, err := CalculateExpirationTimeAt(resource, spec, now)
+	if errors.Is(err, ErrRelativeTTLExpired) {
+		expiresAt, err = now, nil
+	}
+	if err != nil {
+		s.Remove(resource.GetUID())
+		return time.Time{}, err
+	}
+
+	s.ScheduleAt(ScheduledResource{
+		UID:       resource.GetUID(),
+		Namespace: resource.GetNamespace(),
+		Name:      resource.GetName(),
+		ExpiresAt: expiresAt,
+	})
+	return expiresAt, nil
+}
+
+// ScheduleAt tracks a resource with a precomputed expiration time.
+// Scheduling a UID that is already tracked replaces its entry.
+func (s *Scheduler) ScheduleAt(resource ScheduledResource) {
+	s.mu.Lock()
+	s.generation++
+	if item, ok := s.index[resource.UID]; ok {
+		item.resource = resource
+		item.generation = s.generation
+		heap.Fix(&s.items, item.index)
+	} else {
+		item := &scheduledItem{resource: resource, generation: s.generation}
+		heap.Push(&s.items, item)
+		s.index[resource.UID] = item
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// Remove stops tracking a resource, e.g. after it was deleted.
+// Returns true if the resource was tracked.
+func (s *Scheduler) Remove(uid types.UID) bool {
+	s.mu.Lock()
+	item, ok := s.index[uid]
+	if ok {
+		heap.Remove(&s.items, item.index)
+		delete(s.index, uid)
+	}
+	s.mu.Unlock()
+
+	if ok {
+		s.notify()
+	}
+	return ok
+}
+
+// NextExpiry returns the earliest expiration time among tracked resources.
+// Returns false if no resources are tracked.
+func (s *Scheduler) NextExpiry() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.items) == 0 {
+		return time.Time{}, false
+	}
+	return s.items[0].resource.ExpiresAt, true
+}
+
+// Len returns the number of tracked resources.
+func (s *Scheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// PopExpired removes and returns all resources that have expired by now,
+// ordered by expiration time. Use this when driving the scheduler from an
+// existing loop instead of Run.
+func (s *Scheduler) PopExpired() []ScheduledResource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var due []ScheduledResource
+	for len(s.items) > 0 && !s.items[0].resource.ExpiresAt.After(now) {
+		item := heap.Pop(&s.items).(*scheduledItem)
+		delete(s.index, item.resource.UID)
+		due = append(due, item.resource)
+	}
+	return due
+}
+
+// Expired returns the channel on which Run delivers expired resources.
+func (s *Scheduler) Expired() <-chan ScheduledResource {
+	return s.expired
+}
+
+// Run delivers expired resources on the Expired() channel until the context is canceled.
+// It sleeps until the next expiry and wakes early when the schedule changes.
+// Run blocks while the channel is full; it must be called at most once.
+//
+// An expired resource stays tracked until Run has handed it to the channel, so
+// removing or rescheduling it while Run is blocked on a full channel cancels
+// its delivery. A change that races with the hand-off itself can still be
+// delivered, so consumers should confirm the resource is still expired.
+func (s *Scheduler) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		if resource, generation, ok := s.peekExpired(); ok {
+			select {
+			case s.expired <- resource:
+				s.removeIfCurrent(resource.UID, generation)
+			case <-s.wake:
+				// The schedule changed while blocked; re-check the head
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		// Sleep until the next expiry (or indefinitely if nothing is tracked)
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		var timerC <-chan time.Time
+		if next, ok := s.NextExpiry(); ok {
+			timer.Reset(next.Sub(s.now()))
+			timerC = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-timerC:
+		}
+	}
+}
+
+// peekExpired returns the earliest resource if it has expired by now, and the
+// generation of its entry, without removing it.
+func (s *Scheduler) peekExpired() (ScheduledResource, uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.items) == 0 || s.items[0].resource.ExpiresAt.After(s.now()) {
+		return ScheduledResource{}, 0, false
+	}
+	return s.items[0].resource, s.items[0].generation, true
+}
+
+// removeIfCurrent stops tracking uid after delivery, unless it was removed or
+// rescheduled since it was peeked.
+func (s *Scheduler) removeIfCurrent(uid types.UID, generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item, ok := s.index[uid]; ok && item.generation == generation {
+		heap.Remove(&s.items, item.index)
+		delete(s.index, uid)
+	}
+}
+
+// notify wakes Run so it can recompute the next expiry.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// scheduledItem is a heap entry.
+type scheduledItem struct {
+	resource   ScheduledResource
+	generation uint64
+	index      int
+}
+
+// expiryHeap is a min-heap of scheduled items ordered by expiration time.
+type expiryHeap []*scheduledItem
+
+func (h expiryHeap) Len() int { return len(h) }
+
+func (h expiryHeap) Less(i, j int) bool {
+	return h[i].resource.ExpiresAt.Before(h[j].resource.ExpiresAt)
+}
+
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expiryHeap) Push(x any) {
+	item := x.(*scheduledItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
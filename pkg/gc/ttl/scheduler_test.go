@@ -0,0 +1,264 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ttl
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeClock is a manually advanced clock for scheduler tests.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newScheduledResource(uid, name string, created time.Time, ttlSeconds int64) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"ttlSeconds": ttlSeconds,
+			},
+		},
+	}
+	resource.SetUID(types.UID(uid))
+	resource.SetNamespace("default")
+	resource.SetName(name)
+	resource.SetCreationTimestamp(metav1.Time{Time: created})
+	return resource
+}
+
+func TestScheduler_NextExpiryOrdering(t *testing.T) {
+	clock := &fakeClock{now: time.Now().Truncate(time.Second)}
+	s := NewScheduler(SchedulerConfig{Now: clock.Now})
+	spec := &Spec{FieldPath: "spec.ttlSeconds"}
+
+	if _, ok := s.NextExpiry(); ok {
+		t.Fatal("NextExpiry() on empty scheduler should return false")
+	}
+
+	for _, r := range []*unstructured.Unstructured{
+		newScheduledResource("a", "a", clock.Now(), 300),
+		newScheduledResource("b", "b", clock.Now(), 60),
+		newScheduledResource("c", "c", clock.Now(), 120),
+	} {
+		if _, err := s.Schedule(r, spec); err != nil {
+			t.Fatalf("Schedule() unexpected error: %v", err)
+		}
+	}
+
+	if s.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", s.Len())
+	}
+	next, ok := s.NextExpiry()
+	if !ok || !next.Equal(clock.Now().Add(60*time.Second)) {
+		t.Errorf("NextExpiry() = %v, %v, want %v", next, ok, clock.Now().Add(60*time.Second))
+	}
+
+	clock.Advance(150 * time.Second)
+	due := s.PopExpired()
+	if len(due) != 2 || due[0].UID != "b" || due[1].UID != "c" {
+		t.Fatalf("PopExpired() = %+v, want [b c]", due)
+	}
+	if due[0].NamespacedName() != (types.NamespacedName{Namespace: "default", Name: "b"}) {
+		t.Errorf("NamespacedName() = %v", due[0].NamespacedName())
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() after PopExpired = %d, want 1", s.Len())
+	}
+}
+
+func TestScheduler_UpdateChangesTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Now().Truncate(time.Second)}
+	s := NewScheduler(SchedulerConfig{Now: clock.Now})
+	spec := &Spec{FieldPath: "spec.ttlSeconds"}
+
+	resource := newScheduledResource("a", "a", clock.Now(), 60)
+	if _, err := s.Schedule(resource, spec); err != nil {
+		t.Fatalf("Schedule() unexpected error: %v", err)
+	}
+
+	// Extend the TTL
+	if err := unstructured.SetNestedField(resource.Object, int64(600), "spec", "ttlSeconds"); err != nil {
+		t.Fatal(err)
+	}
+	expiresAt, err := s.Schedule(resource, spec)
+	if err != nil {
+		t.Fatalf("Schedule() unexpected error: %v", err)
+	}
+	if !expiresAt.Equal(clock.Now().Add(600 * time.Second)) {
+		t.Errorf("Schedule() = %v, want %v", expiresAt, clock.Now().Add(600*time.Second))
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 (update must not duplicate)", s.Len())
+	}
+
+	clock.Advance(120 * time.Second)
+	if due := s.PopExpired(); len(due) != 0 {
+		t.Errorf("PopExpired() = %+v, want none after TTL extension", due)
+	}
+}
+
+func TestScheduler_InvalidSpecUnschedules(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	s := NewScheduler(SchedulerConfig{Now: clock.Now})
+
+	resource := newScheduledResource("a", "a", clock.Now(), 60)
+	if _, err := s.Schedule(resource, &Spec{FieldPath: "spec.ttlSeconds"}); err != nil {
+		t.Fatalf("Schedule() unexpected error: %v", err)
+	}
+
+	_, err := s.Schedule(resource, &Spec{FieldPath: "spec.missing"})
+	if !errors.Is(err, ErrFieldPathNotFound) {
+		t.Errorf("Schedule() error = %v, want ErrFieldPathNotFound", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", s.Len())
+	}
+}
+
+func TestScheduler_Remove(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	s := NewScheduler(SchedulerConfig{Now: clock.Now})
+	spec := &Spec{FieldPath: "spec.ttlSeconds"}
+
+	for _, uid := range []string{"a", "b", "c"} {
+		if _, err := s.Schedule(newScheduledResource(uid, uid, clock.Now(), 60), spec); err != nil {
+			t.Fatalf("Schedule() unexpected error: %v", err)
+		}
+	}
+
+	if !s.Remove("b") {
+		t.Error("Remove(b) = false, want true")
+	}
+	if s.Remove("b") {
+		t.Error("Remove(b) second call = true, want false")
+	}
+
+	clock.Advance(time.Hour)
+	due := s.PopExpired()
+	if len(due) != 2 {
+		t.Fatalf("PopExpired() returned %d resources, want 2", len(due))
+	}
+	for _, r := range due {
+		if r.UID == "b" {
+			t.Error("removed resource was returned by PopExpired()")
+		}
+	}
+}
+
+func TestScheduler_RelativeTTLAlreadyExpired(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	s := NewScheduler(SchedulerConfig{Now: clock.Now})
+
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"lastProcessedAt": time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+			},
+		},
+	}
+	resource.SetUID("a")
+
+	secondsAfter := int64(3600)
+	_, err := s.Schedule(resource, &Spec{RelativeTo: "status.lastProcessedAt", SecondsAfter: &secondsAfter})
+	if err != nil {
+		t.Fatalf("Schedule() unexpected error: %v", err)
+	}
+	if due := s.PopExpired(); len(due) != 1 {
+		t.Errorf("PopExpired() returned %d resources, want 1", len(due))
+	}
+}
+
+func TestScheduler_Run(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go s.Run(ctx)
+
+	now := time.Now()
+	s.ScheduleAt(ScheduledResource{UID: "later", ExpiresAt: now.Add(200 * time.Millisecond)})
+	s.ScheduleAt(ScheduledResource{UID: "soon", ExpiresAt: now.Add(50 * time.Millisecond)})
+
+	for _, want := range []types.UID{"soon", "later"} {
+		select {
+		case r := <-s.Expired():
+			if r.UID != want {
+				t.Errorf("Expired() = %s, want %s", r.UID, want)
+			}
+			if time.Now().Before(r.ExpiresAt) {
+				t.Errorf("resource %s delivered before its expiry", r.UID)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
+
+func TestScheduler_RunSkipsRemovedWhileBlocked(t *testing.T) {
+	s := NewScheduler(SchedulerConfig{BufferSize: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	s.ScheduleAt(ScheduledResource{UID: "first", ExpiresAt: now.Add(-2 * time.Second)})
+	s.ScheduleAt(ScheduledResource{UID: "removed", ExpiresAt: now.Add(-time.Second)})
+	s.ScheduleAt(ScheduledResource{UID: "rescheduled", ExpiresAt: now})
+	go s.Run(ctx)
+
+	// "first" fills the buffer; Run is now blocked delivering "removed"
+	for s.Len() != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if !s.Remove("removed") {
+		t.Fatal("Remove(removed) = false, want true while Run is blocked")
+	}
+	s.ScheduleAt(ScheduledResource{UID: "rescheduled", ExpiresAt: now.Add(time.Hour)})
+
+	if r := <-s.Expired(); r.UID != "first" {
+		t.Fatalf("Expired() = %s, want first", r.UID)
+	}
+	select {
+	case r := <-s.Expired():
+		t.Errorf("Expired() delivered %s after it was removed or rescheduled", r.UID)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 (rescheduled)", s.Len())
+	}
+}
@@ -28,7 +28,7 @@ type Spec struct {
 	// FieldPath is a dot-separated path to a field containing the TTL value.
 	// The field can be:
 	// - int64: TTL in seconds (e.g., spec.ttlSeconds: 3600)
-	// - string: Used with Mappings for mapped TTL
+	// - string: A duration (e.g., ttl: "7d"), or a key for Mappings/DurationMappings
 	// Example: "spec.ttlSeconds"
 	FieldPath string
 
@@ -36,11 +36,20 @@ type Spec struct {
 	// Example: {"critical": 86400, "normal": 3600, "low": 1800}
 	Mappings map[string]int64
 
+	// DurationMappings define TTLs as duration strings based on field value.
+	// Consulted when the field value has no entry in Mappings.
+	// Example: {"critical": "30d", "normal": "36h", "low": "30m"}
+	DurationMappings map[string]string
+
 	// Default is the fallback TTL when:
 	// - FieldPath field is not found, OR
 	// - Field value has no mapping and Default is set
 	Default *int64
 
+	// DefaultDuration is the fallback TTL as a duration string (e.g., "7d").
+	// Only used when Default is nil.
+	DefaultDuration string
+
 	// RelativeTo is a field path to a timestamp field (RFC3339 format).
 	// Used with SecondsAfter to calculate TTL relative to a specific timestamp.
 	// Example: "status.lastProcessedAt"
@@ -18,9 +18,10 @@ package leader
 
 import (
 	"fmt"
-	"os"
 	"time"
 
+	"github.com/kube-zen/zen-sdk/pkg/logging"
+	"github.com/kube-zen/zen-sdk/pkg/zenlead"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -31,6 +32,10 @@ import (
 // Other controllers expose a flag/env var that defaults to true but can be set to false
 // if the user doesn't want HA or wants zen-lead to handle HA instead.
 //
+// The arguments are converted with ElectionConfig and applied with the same
+// settings as zenlead.PrepareManagerOptions. An invalid config is logged as a
+// warning and applied anyway; use ApplyLeaderElectionStrict to reject it.
+//
 // Parameters:
 //   - opts: Pointer to ctrl.Options to modify
 //   - component: Component name (e.g., "zen-flow-controller", "zen-gc-controller")
@@ -38,26 +43,99 @@ import (
 //   - idOverride: Optional override for leader election ID. If empty, uses component-based ID.
 //   - enable: Whether to enable leader election (default: true for all controllers except zen-lead which is always true)
 func ApplyLeaderElection(opts *ctrl.Options, component string, namespace string, idOverride string, enable bool) {
+	applyConfig(opts, ElectionConfig(component, namespace, idOverride, enable))
+}
+
+// ApplyLeaderElectionStrict is ApplyLeaderElection that returns an error for
+// an invalid config, e.g. a timing below its minimum, and leaves opts unchanged.
+func ApplyLeaderElectionStrict(opts *ctrl.Options, component string, namespace string, idOverride string, enable bool) error {
+	return applyConfigStrict(opts, ElectionConfig(component, namespace, idOverride, enable))
+}
+
+// ElectionConfig converts the ApplyLeaderElection arguments to a zenlead.LeaderElectionConfig.
+func ElectionConfig(component string, namespace string, idOverride string, enable bool) *zenlead.LeaderElectionConfig {
 	if !enable {
-		opts.LeaderElection = false
-		return
+		return &zenlead.LeaderElectionConfig{Mode: zenlead.Disabled}
 	}
 
-	// Enable leader election
-	opts.LeaderElection = true
+	electionID := idOverride
+	if electionID == "" {
+		electionID = fmt.Sprintf("%s-leader-election", component)
+	}
+	return &zenlead.LeaderElectionConfig{
+		Mode:       zenlead.BuiltIn,
+		ElectionID: electionID,
+		Namespace:  namespace,
+	}
+}
 
-	// Set leader election ID
-	if idOverride != "" {
-		opts.LeaderElectionID = idOverride
-	} else {
-		opts.LeaderElectionID = fmt.Sprintf("%s-leader-election", component)
+// applyConfig applies le as the legacy API always has: leader election is
+// enabled with le's ID, namespace and timings even if le is invalid, and the
+// problem is logged as a warning.
+func applyConfig(opts *ctrl.Options, le *zenlead.LeaderElectionConfig) {
+	if err := validateConfig(le); err != nil {
+		logging.NewLogger("leader").Warn("Invalid leader election config, applying it anyway",
+			logging.Operation("leader_election_setup"),
+			logging.ErrorCode("LEADER_ELECTION_CONFIG_INVALID"),
+			logging.Error(err))
 	}
+	setConfig(opts, le)
+}
+
+// applyConfigStrict applies le only if it is valid.
+func applyConfigStrict(opts *ctrl.Options, le *zenlead.LeaderElectionConfig) error {
+	if err := validateConfig(le); err != nil {
+		return err
+	}
+	setConfig(opts, le)
+	return nil
+}
 
-	// Set namespace (required when enabled)
-	opts.LeaderElectionNamespace = namespace
+// validateConfig checks le as zenlead.ApplyConfig does, except that an empty
+// namespace is allowed: controller-runtime resolves it in-cluster.
+func validateConfig(le *zenlead.LeaderElectionConfig) error {
+	if le.Mode == zenlead.Disabled {
+		return nil
+	}
+	if le.ElectionID == "" {
+		return fmt.Errorf("invalid leader election config: ElectionID is required for BuiltIn mode")
+	}
+	if err := le.ValidateTimings(); err != nil {
+		return fmt.Errorf("invalid leader election config: %w", err)
+	}
+	return nil
+}
 
-	// Set ReleaseOnCancel to ensure clean shutdown
+// setConfig writes le to opts without validating it. Valid timings are
+// resolved as zenlead.ApplyConfig does; invalid ones are written as given.
+func setConfig(opts *ctrl.Options, le *zenlead.LeaderElectionConfig) {
+	if le.Mode == zenlead.Disabled {
+		opts.LeaderElection = false
+		return
+	}
+	opts.LeaderElection = true
+	opts.LeaderElectionID = le.ElectionID
+	opts.LeaderElectionNamespace = le.Namespace
 	opts.LeaderElectionReleaseOnCancel = true
+
+	if le.Profile == "" && le.LeaseDuration == nil && le.RenewDeadline == nil && le.RetryPeriod == nil {
+		return
+	}
+	if t, err := le.EffectiveTimings(); err == nil {
+		opts.LeaseDuration = &t.LeaseDuration
+		opts.RenewDeadline = &t.RenewDeadline
+		opts.RetryPeriod = &t.RetryPeriod
+		return
+	}
+	if le.LeaseDuration != nil {
+		opts.LeaseDuration = le.LeaseDuration
+	}
+	if le.RenewDeadline != nil {
+		opts.RenewDeadline = le.RenewDeadline
+	}
+	if le.RetryPeriod != nil {
+		opts.RetryPeriod = le.RetryPeriod
+	}
 }
 
 // ApplyRequiredLeaderElection is a convenience wrapper for ApplyLeaderElection that always enables leader election.
@@ -74,24 +152,15 @@ func ApplyRequiredLeaderElection(opts *ctrl.Options, component string, namespace
 //   - namespace: Pod namespace
 //   - error: If namespace cannot be determined
 func RequirePodNamespace() (string, error) {
-	// Try environment variable first (Downward API)
-	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
-		return ns, nil
-	}
-
-	// Fallback to service account namespace file
-	if data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err == nil {
-		if ns := string(data); ns != "" {
-			return ns, nil
-		}
-	}
-
-	return "", fmt.Errorf("POD_NAMESPACE environment variable must be set or service account namespace file must be readable")
+	return zenlead.PodNamespace()
 }
 
 // ApplyRestConfigDefaults sets recommended REST client defaults for controller-runtime.
 // This ensures consistent QPS/Burst settings across all controllers using zen-sdk.
 //
+// Deprecated: Use zenlead.ControllerRuntimeDefaults, which also sets a
+// UserAgent and a request Timeout. This function only sets QPS and Burst.
+//
 // Parameters:
 //   - config: Pointer to rest.Config to modify
 func ApplyRestConfigDefaults(config *rest.Config) {
@@ -135,44 +204,53 @@ func DefaultOptions(leaseName string) Options {
 	}
 }
 
-// Setup configures leader election options for controller-runtime Manager (legacy API)
+// Config converts legacy Options to a zenlead.LeaderElectionConfig.
+// Zero durations are left to controller-runtime defaults.
+func (o Options) Config() *zenlead.LeaderElectionConfig {
+	if !o.Enable {
+		return &zenlead.LeaderElectionConfig{Mode: zenlead.Disabled}
+	}
+
+	le := &zenlead.LeaderElectionConfig{
+		Mode:       zenlead.BuiltIn,
+		ElectionID: o.LeaseName,
+		Namespace:  o.Namespace,
+	}
+	if o.LeaseDuration > 0 {
+		d := o.LeaseDuration
+		le.LeaseDuration = &d
+	}
+	if o.RenewDeadline > 0 {
+		d := o.RenewDeadline
+		le.RenewDeadline = &d
+	}
+	if o.RetryPeriod > 0 {
+		d := o.RetryPeriod
+		le.RetryPeriod = &d
+	}
+	return le
+}
+
+// Setup configures leader election options for controller-runtime Manager (legacy API).
+// Options are converted with Options.Config and applied like ApplyLeaderElection,
+// so an invalid config is logged as a warning and applied anyway.
 func Setup(opts Options) func(*ctrl.Options) {
 	return func(managerOpts *ctrl.Options) {
 		if !opts.Enable {
 			return
 		}
+		applyConfig(managerOpts, opts.Config())
+	}
+}
 
-		if opts.LeaseName != "" {
-			managerOpts.LeaderElectionID = opts.LeaseName
-		}
-
-		managerOpts.LeaderElection = true
-		managerOpts.LeaderElectionReleaseOnCancel = true
-
-		if opts.Namespace != "" {
-			managerOpts.LeaderElectionNamespace = opts.Namespace
-		}
-
-		if opts.LeaseDuration > 0 {
-			managerOpts.LeaseDuration = func() *time.Duration {
-				d := opts.LeaseDuration
-				return &d
-			}()
-		}
-
-		if opts.RenewDeadline > 0 {
-			managerOpts.RenewDeadline = func() *time.Duration {
-				d := opts.RenewDeadline
-				return &d
-			}()
-		}
-
-		if opts.RetryPeriod > 0 {
-			managerOpts.RetryPeriod = func() *time.Duration {
-				d := opts.RetryPeriod
-				return &d
-			}()
+// SetupStrict is Setup that returns an error for an invalid config and
+// leaves the manager options unchanged.
+func SetupStrict(opts Options) func(*ctrl.Options) error {
+	return func(managerOpts *ctrl.Options) error {
+		if !opts.Enable {
+			return nil
 		}
+		return applyConfigStrict(managerOpts, opts.Config())
 	}
 }
 
@@ -182,3 +260,11 @@ func ManagerOptions(baseOpts *ctrl.Options, leaderOpts Options) ctrl.Options {
 	setupFunc(baseOpts)
 	return *baseOpts
 }
+
+// ManagerOptionsStrict is ManagerOptions that returns an error for an invalid config.
+func ManagerOptionsStrict(baseOpts *ctrl.Options, leaderOpts Options) (ctrl.Options, error) {
+	if err := SetupStrict(leaderOpts)(baseOpts); err != nil {
+		return ctrl.Options{}, err
+	}
+	return *baseOpts, nil
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package leader
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/kube-zen/zen-sdk/pkg/zenlead"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -114,3 +117,107 @@ func TestManagerOptions(t *testing.T) {
 		t.Errorf("Expected LeaderElectionID 'test-lease', got '%s'", result.LeaderElectionID)
 	}
 }
+
+func TestApplyLeaderElection_MatchesZenlead(t *testing.T) {
+	var legacy ctrl.Options
+	ApplyLeaderElection(&legacy, "zen-gc", "zen-system", "", true)
+
+	unified, err := zenlead.PrepareManagerOptions(&ctrl.Options{}, &zenlead.LeaderElectionConfig{
+		Mode:       zenlead.BuiltIn,
+		ElectionID: "zen-gc-leader-election",
+		Namespace:  "zen-system",
+	})
+	if err != nil {
+		t.Fatalf("PrepareManagerOptions failed: %v", err)
+	}
+	if legacy.LeaderElection != unified.LeaderElection ||
+		legacy.LeaderElectionID != unified.LeaderElectionID ||
+		legacy.LeaderElectionNamespace != unified.LeaderElectionNamespace ||
+		legacy.LeaderElectionReleaseOnCancel != unified.LeaderElectionReleaseOnCancel {
+		t.Errorf("ApplyLeaderElection() = %+v, want the zenlead result %+v", legacy, unified)
+	}
+
+	var disabled ctrl.Options
+	ApplyLeaderElection(&disabled, "zen-gc", "zen-system", "", false)
+	if disabled.LeaderElection {
+		t.Error("Expected LeaderElection to be false when disabled")
+	}
+}
+
+func TestApplyLeaderElection_Validation(t *testing.T) {
+	// Without a namespace, controller-runtime resolves it in-cluster
+	var inCluster ctrl.Options
+	if err := ApplyLeaderElectionStrict(&inCluster, "zen-gc", "", "", true); err != nil {
+		t.Fatalf("ApplyLeaderElectionStrict() without namespace unexpected error: %v", err)
+	}
+	if !inCluster.LeaderElection || inCluster.LeaderElectionID != "zen-gc-leader-election" || inCluster.LeaderElectionNamespace != "" {
+		t.Errorf("ApplyLeaderElectionStrict() without namespace = %+v, want enabled with no namespace", inCluster)
+	}
+
+	// The legacy functions keep leader election on for an invalid config
+	var noID ctrl.Options
+	Setup(Options{Enable: true, Namespace: "test-ns"})(&noID)
+	if !noID.LeaderElection || noID.LeaderElectionNamespace != "test-ns" {
+		t.Errorf("Setup() without LeaseName = %+v, want enabled", noID)
+	}
+
+	opts := DefaultOptions("test-lease")
+	opts.Enable = true
+	opts.Namespace = "test-ns"
+	opts.RenewDeadline = 30 * time.Second
+	var invalid ctrl.Options
+	Setup(opts)(&invalid)
+	if !invalid.LeaderElection || invalid.LeaderElectionID != "test-lease" ||
+		invalid.RenewDeadline == nil || *invalid.RenewDeadline != 30*time.Second {
+		t.Errorf("Setup() with RenewDeadline > LeaseDuration = %+v, want applied as given", invalid)
+	}
+
+	// The strict functions reject it and leave the options unchanged
+	var strict ctrl.Options
+	if err := SetupStrict(opts)(&strict); err == nil || !errors.Is(err, zenlead.ErrInvalidTiming) {
+		t.Errorf("SetupStrict() error = %v, want %v", err, zenlead.ErrInvalidTiming)
+	}
+	if strict.LeaderElection || strict.RenewDeadline != nil {
+		t.Errorf("SetupStrict() with an invalid config = %+v, want nothing applied", strict)
+	}
+	if _, err := ManagerOptionsStrict(&ctrl.Options{}, Options{Enable: true}); err == nil {
+		t.Error("ManagerOptionsStrict() without LeaseName expected error")
+	}
+}
+
+func TestElectionConfig(t *testing.T) {
+	le := ElectionConfig("zen-flow", "zen-system", "custom-id", true)
+	if le.Mode != zenlead.BuiltIn || le.ElectionID != "custom-id" || le.Namespace != "zen-system" {
+		t.Errorf("ElectionConfig() = %+v", le)
+	}
+	if le := ElectionConfig("zen-flow", "zen-system", "", false); le.Mode != zenlead.Disabled {
+		t.Errorf("ElectionConfig(enable=false) mode = %q, want disabled", le.Mode)
+	}
+}
+
+func TestOptionsConfig(t *testing.T) {
+	opts := DefaultOptions("test-lease")
+	opts.Enable = true
+	opts.Namespace = "test-ns"
+
+	le := opts.Config()
+	if le.Mode != zenlead.BuiltIn || le.ElectionID != "test-lease" || le.Namespace != "test-ns" {
+		t.Errorf("Config() = %+v", le)
+	}
+	if le.LeaseDuration == nil || *le.LeaseDuration != 15*time.Second {
+		t.Errorf("Config() LeaseDuration = %v, want 15s", le.LeaseDuration)
+	}
+	if (Options{}).Config().Mode != zenlead.Disabled {
+		t.Error("Expected disabled Options to convert to Disabled mode")
+	}
+}
+
+func TestApplyRestConfigDefaults(t *testing.T) {
+	config := &rest.Config{}
+	ApplyRestConfigDefaults(config)
+
+	// Only QPS and Burst, unlike zenlead.ControllerRuntimeDefaults
+	if config.QPS != 50 || config.Burst != 100 || config.UserAgent != "" || config.Timeout != 0 {
+		t.Errorf("ApplyRestConfigDefaults() = %+v, want QPS 50 and Burst 100 only", config)
+	}
+}
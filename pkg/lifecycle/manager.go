@@ -0,0 +1,381 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/logging"
+)
+
+// DefaultHookTimeout is the default per-hook timeout of a Manager.
+const DefaultHookTimeout = 10 * time.Second
+
+// Phase orders shutdown hooks: lower phases run first. Within a phase, hooks
+// run in reverse registration order, so whatever started last stops first.
+type Phase int
+
+const (
+	// PhasePreStop is for hooks that stop new traffic, e.g. failing readiness.
+	PhasePreStop Phase = 0
+	// PhaseServers is for HTTP and gRPC servers.
+	PhaseServers Phase = 100
+	// PhaseWorkers is for workers and controllers.
+	PhaseWorkers Phase = 200
+	// PhaseCleanup is for flushing telemetry and closing connections.
+	PhaseCleanup Phase = 300
+)
+
+// Hook is a named shutdown step registered with a Manager.
+type Hook struct {
+	// Name identifies the hook in logs and the Summary. Required and unique.
+	Name string
+
+	// Phase orders the hook (default: PhasePreStop).
+	Phase Phase
+
+	// Timeout bounds Stop (default: the Manager's DefaultTimeout).
+	Timeout time.Duration
+
+	// Stop shuts the component down. Its context is cancelled after Timeout. Required.
+	Stop func(ctx context.Context) error
+
+	// ForceStop, if set, is called when Stop has not returned within Timeout
+	// (e.g. grpc.Server.Stop, http.Server.Close).
+	ForceStop func()
+}
+
+// HookResult is the outcome of one hook.
+type HookResult struct {
+	Name     string
+	Phase    Phase
+	Duration time.Duration
+	Err      error
+	// TimedOut is true if Stop did not return within the hook's Timeout.
+	TimedOut bool
+	// Canceled is true if the context passed to Shutdown was done before Stop
+	// returned. The hook is not force-stopped in that case.
+	Canceled bool
+	// Forced is true if ForceStop was called.
+	Forced bool
+}
+
+// Summary reports a Manager shutdown, with results in the order hooks ran.
+type Summary struct {
+	Results  []HookResult
+	Duration time.Duration
+}
+
+// Err joins the errors of failed hooks, or returns nil if all succeeded.
+func (s *Summary) Err() error {
+	var errs []error
+	for _, r := range s.Results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Name, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Failed returns the number of hooks whose Err is non-nil, which includes hooks
+// that returned an error, timed out or were canceled.
+func (s *Summary) Failed() int {
+	failed := 0
+	for _, r := range s.Results {
+		if r.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Manager runs registered shutdown hooks in order, each with its own timeout,
+// and logs a summary with a single component logger.
+//
+// Usage:
+//
+//	m := lifecycle.NewManager("my-component")
+//	m.RegisterHTTPServer("metrics", metricsServer, lifecycle.PhaseServers, 5*time.Second)
+//	m.RegisterGRPCServer("api", grpcServer, lifecycle.PhaseServers, 20*time.Second)
+//
+//	ctx, cancel := m.ShutdownContext(context.Background())
+//	defer cancel()
+//	summary := m.Wait(ctx)
+//	if err := summary.Err(); err != nil {
+//	    os.Exit(1)
+//	}
+type Manager struct {
+	component string
+	logger    *logging.Logger
+
+	// DefaultTimeout applies to hooks without a Timeout (default: DefaultHookTimeout).
+	DefaultTimeout time.Duration
+
+	mu      sync.Mutex
+	hooks   []Hook
+	once    sync.Once
+	summary *Summary
+}
+
+// NewManager creates a Manager for component.
+func NewManager(component string) *Manager {
+	return &Manager{
+		component:      component,
+		logger:         logging.NewLogger(component),
+		DefaultTimeout: DefaultHookTimeout,
+	}
+}
+
+// Register adds a shutdown hook. It fails if Name is empty or already
+// registered, if Stop is nil, or if shutdown has already started.
+func (m *Manager) Register(hook Hook) error {
+	if hook.Name == "" {
+		return fmt.Errorf("hook Name is required")
+	}
+	if hook.Stop == nil {
+		return fmt.Errorf("hook %q: Stop is required", hook.Name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.summary != nil {
+		return fmt.Errorf("hook %q: shutdown already started", hook.Name)
+	}
+	for _, h := range m.hooks {
+		if h.Name == hook.Name {
+			return fmt.Errorf("hook %q is already registered", hook.Name)
+		}
+	}
+	m.hooks = append(m.hooks, hook)
+	return nil
+}
+
+// RegisterFunc registers fn as a hook without a force-stop.
+func (m *Manager) RegisterFunc(name string, phase Phase, timeout time.Duration, fn func(ctx context.Context) error) error {
+	return m.Register(Hook{Name: name, Phase: phase, Timeout: timeout, Stop: fn})
+}
+
+// RegisterHTTPServer registers a hook that calls server.Shutdown, and
+// server.Close if it does not finish within timeout.
+func (m *Manager) RegisterHTTPServer(name string, server *http.Server, phase Phase, timeout time.Duration) error {
+	return m.Register(Hook{
+		Name:    name,
+		Phase:   phase,
+		Timeout: timeout,
+		Stop: func(ctx context.Context) error {
+			if err := server.Shutdown(ctx); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			return ctx.Err()
+		},
+		ForceStop: func() {
+			_ = server.Close()
+		},
+	})
+}
+
+// RegisterGRPCServer registers a hook that calls server.GracefulStop, and
+// server.Stop if in-flight RPCs do not finish within timeout.
+func (m *Manager) RegisterGRPCServer(name string, server GRPCServer, phase Phase, timeout time.Duration) error {
+	return m.Register(Hook{
+		Name:    name,
+		Phase:   phase,
+		Timeout: timeout,
+		Stop: func(ctx context.Context) error {
+			return gracefulStop(ctx, server)
+		},
+		ForceStop: server.Stop,
+	})
+}
+
+// ShutdownContext is ShutdownContext with the Manager's component name.
+func (m *Manager) ShutdownContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return ShutdownContext(ctx, m.component)
+}
+
+// Wait blocks until ctx is cancelled (typically a ShutdownContext), then runs
+// Shutdown with a fresh context.
+func (m *Manager) Wait(ctx context.Context) *Summary {
+	<-ctx.Done()
+	return m.Shutdown(context.Background())
+}
+
+// Shutdown runs the registered hooks by ascending Phase, and within a phase in
+// reverse registration order. A hook that fails or times out does not stop the
+// remaining hooks. ctx bounds the whole shutdown. Shutdown runs the hooks only
+// once; later calls return the first Summary.
+func (m *Manager) Shutdown(ctx context.Context) *Summary {
+	m.once.Do(func() {
+		m.mu.Lock()
+		m.summary = &Summary{}
+		hooks := orderHooks(m.hooks)
+		m.mu.Unlock()
+
+		began := time.Now()
+		m.logger.Info("Running shutdown hooks",
+			logging.Operation("shutdown"),
+			logging.Int("hooks", len(hooks)))
+
+		results := make([]HookResult, 0, len(hooks))
+		for _, hook := range hooks {
+			results = append(results, m.runHook(ctx, hook))
+		}
+
+		m.mu.Lock()
+		m.summary.Results = results
+		m.summary.Duration = time.Since(began)
+		m.mu.Unlock()
+		m.logSummary(m.summary)
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.summary
+}
+
+// runHook runs one hook with its timeout, force-stopping it on expiry. If ctx
+// is done first, the hook is reported as canceled and not force-stopped.
+func (m *Manager) runHook(ctx context.Context, hook Hook) HookResult {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = m.DefaultTimeout
+	}
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	result := HookResult{Name: hook.Name, Phase: hook.Phase}
+
+	m.logger.Info("Running shutdown hook",
+		logging.Operation("shutdown_hook"),
+		logging.Name(hook.Name),
+		logging.Int("phase", int(hook.Phase)),
+		logging.Duration("timeout", timeout))
+
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	began := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- hook.Stop(hookCtx)
+	}()
+
+	select {
+	case err := <-done:
+		result.Err = err
+	case <-hookCtx.Done():
+		if ctx.Err() != nil {
+			result.Err = fmt.Errorf("shutdown canceled before the hook stopped: %w", ctx.Err())
+		} else {
+			result.Err = fmt.Errorf("did not stop within %s: %w", timeout, hookCtx.Err())
+		}
+	}
+	if result.Err != nil && hookCtx.Err() != nil {
+		// ctx ending first is a cancellation, not the hook's own timeout
+		if ctx.Err() != nil {
+			result.Canceled = true
+		} else {
+			result.TimedOut = true
+		}
+	}
+	if result.TimedOut && hook.ForceStop != nil {
+		result.Forced = true
+		hook.ForceStop()
+	}
+	result.Duration = time.Since(began)
+
+	if result.Err != nil {
+		m.logger.Error(result.Err, "Shutdown hook failed",
+			logging.Operation("shutdown_hook"),
+			logging.Name(hook.Name),
+			logging.Bool("timed_out", result.TimedOut),
+			logging.Bool("canceled", result.Canceled),
+			logging.Bool("forced", result.Forced),
+			logging.Duration("duration", result.Duration),
+			logging.ErrorCode("SHUTDOWN_HOOK_ERROR"))
+	} else {
+		m.logger.Info("Shutdown hook completed",
+			logging.Operation("shutdown_hook"),
+			logging.Name(hook.Name),
+			logging.Duration("duration", result.Duration))
+	}
+	return result
+}
+
+// logSummary logs the outcome of a shutdown.
+func (m *Manager) logSummary(s *Summary) {
+	forced := 0
+	for _, r := range s.Results {
+		if r.Forced {
+			forced++
+		}
+	}
+	if s.Failed() > 0 {
+		m.logger.Warn("Shutdown completed with failures",
+			logging.Operation("shutdown_complete"),
+			logging.Int("hooks", len(s.Results)),
+			logging.Int("failed", s.Failed()),
+			logging.Int("forced", forced),
+			logging.Duration("duration", s.Duration))
+		return
+	}
+	m.logger.Info("Shutdown complete",
+		logging.Operation("shutdown_complete"),
+		logging.Int("hooks", len(s.Results)),
+		logging.Duration("duration", s.Duration))
+}
+
+// orderHooks returns hooks sorted by ascending Phase, each phase in reverse
+// registration order.
+func orderHooks(hooks []Hook) []Hook {
+	ordered := make([]Hook, len(hooks))
+	for i, hook := range hooks {
+		ordered[len(hooks)-1-i] = hook
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Phase < ordered[j].Phase
+	})
+	return ordered
+}
+
+// gracefulStop calls server.GracefulStop and returns ctx.Err() if it has not
+// finished when ctx is done. GracefulStop keeps running in the background
+// until the caller force-stops the server.
+func gracefulStop(ctx context.Context, server GRPCServer) error {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGRPCServer is a GRPCServer whose GracefulStop blocks until Stop is called
+// or, if hang is false, returns immediately.
+type fakeGRPCServer struct {
+	hang    bool
+	stopped chan struct{}
+	once    sync.Once
+	forced  bool
+}
+
+func newFakeGRPCServer(hang bool) *fakeGRPCServer {
+	return &fakeGRPCServer{hang: hang, stopped: make(chan struct{})}
+}
+
+func (s *fakeGRPCServer) GracefulStop() {
+	if s.hang {
+		<-s.stopped
+	}
+}
+
+func (s *fakeGRPCServer) Stop() {
+	s.once.Do(func() {
+		s.forced = true
+		close(s.stopped)
+	})
+}
+
+func TestManager_Order(t *testing.T) {
+	m := NewManager("test-component")
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	for _, hook := range []Hook{
+		{Name: "metrics-server", Phase: PhaseServers, Stop: record("metrics-server")},
+		{Name: "db", Phase: PhaseCleanup, Stop: record("db")},
+		{Name: "api-server", Phase: PhaseServers, Stop: record("api-server")},
+		{Name: "readiness", Phase: PhasePreStop, Stop: record("readiness")},
+		{Name: "worker", Phase: PhaseWorkers, Stop: record("worker")},
+	} {
+		if err := m.Register(hook); err != nil {
+			t.Fatalf("Register(%s) unexpected error: %v", hook.Name, err)
+		}
+	}
+
+	summary := m.Shutdown(context.Background())
+	want := []string{"readiness", "api-server", "metrics-server", "worker", "db"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("hook order = %v, want %v", order, want)
+	}
+	if len(summary.Results) != 5 || summary.Err() != nil || summary.Failed() != 0 {
+		t.Errorf("Shutdown() = %+v, want 5 successful hooks", summary)
+	}
+	for i, r := range summary.Results {
+		if r.Name != want[i] {
+			t.Errorf("Results[%d] = %s, want %s", i, r.Name, want[i])
+		}
+	}
+}
+
+func TestManager_TimeoutForcesStop(t *testing.T) {
+	m := NewManager("test-component")
+	blocked := make(chan struct{})
+	forced := false
+	if err := m.Register(Hook{
+		Name:      "stuck",
+		Timeout:   50 * time.Millisecond,
+		Stop:      func(context.Context) error { <-blocked; return nil },
+		ForceStop: func() { forced = true; close(blocked) },
+	}); err != nil {
+		t.Fatalf("Register() unexpected error: %v", err)
+	}
+	after := false
+	_ = m.RegisterFunc("after", PhaseCleanup, 0, func(context.Context) error {
+		after = true
+		return nil
+	})
+
+	start := time.Now()
+	summary := m.Shutdown(context.Background())
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Shutdown() took %v, want the hook timeout to apply", elapsed)
+	}
+	r := summary.Results[0]
+	if !r.TimedOut || !r.Forced || !forced || r.Err == nil {
+		t.Errorf("Results[0] = %+v, want timed out and forced", r)
+	}
+	if !after {
+		t.Error("hooks after a timed-out hook did not run")
+	}
+	if summary.Failed() != 1 || !strings.Contains(summary.Err().Error(), "stuck") {
+		t.Errorf("Summary Err() = %v, want the stuck hook", summary.Err())
+	}
+}
+
+func TestManager_CanceledIsNotTimeout(t *testing.T) {
+	m := NewManager("test-component")
+	forced := false
+	if err := m.Register(Hook{
+		Name:      "slow",
+		Timeout:   time.Minute,
+		Stop:      func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() },
+		ForceStop: func() { forced = true },
+	}); err != nil {
+		t.Fatalf("Register() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r := m.Shutdown(ctx).Results[0]
+	if !r.Canceled || r.TimedOut || r.Forced || forced || r.Err == nil {
+		t.Errorf("Results[0] = %+v, want canceled, not timed out or forced", r)
+	}
+}
+
+func TestManager_ErrorsAndPanics(t *testing.T) {
+	m := NewManager("test-component")
+	wantErr := errors.New("flush failed")
+	_ = m.RegisterFunc("flush", PhaseCleanup, time.Second, func(context.Context) error { return wantErr })
+	_ = m.RegisterFunc("panics", PhaseWorkers, time.Second, func(context.Context) error { panic("boom") })
+
+	summary := m.Shutdown(context.Background())
+	if summary.Failed() != 2 {
+		t.Fatalf("Failed() = %d, want 2", summary.Failed())
+	}
+	if !errors.Is(summary.Err(), wantErr) {
+		t.Errorf("Err() = %v, want it to wrap %v", summary.Err(), wantErr)
+	}
+	if !strings.Contains(summary.Results[0].Err.Error(), "panic: boom") {
+		t.Errorf("Results[0].Err = %v, want the panic", summary.Results[0].Err)
+	}
+}
+
+func TestManager_Register(t *testing.T) {
+	m := NewManager("test-component")
+	noop := func(context.Context) error { return nil }
+	if err := m.Register(Hook{Stop: noop}); err == nil {
+		t.Error("Register() accepted a hook without a Name")
+	}
+	if err := m.Register(Hook{Name: "nil-stop"}); err == nil {
+		t.Error("Register() accepted a hook without Stop")
+	}
+	if err := m.RegisterFunc("a", PhaseWorkers, 0, noop); err != nil {
+		t.Fatalf("RegisterFunc() unexpected error: %v", err)
+	}
+	if err := m.RegisterFunc("a", PhaseCleanup, 0, noop); err == nil {
+		t.Error("Register() accepted a duplicate Name")
+	}
+
+	first := m.Shutdown(context.Background())
+	if second := m.Shutdown(context.Background()); second != first {
+		t.Error("Shutdown() ran twice")
+	}
+	if err := m.RegisterFunc("late", PhaseWorkers, 0, noop); err == nil {
+		t.Error("Register() accepted a hook after shutdown")
+	}
+}
+
+func TestManager_Wait(t *testing.T) {
+	m := NewManager("test-component")
+	ran := make(chan struct{})
+	_ = m.RegisterFunc("hook", PhaseWorkers, 0, func(context.Context) error {
+		close(ran)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan *Summary, 1)
+	go func() { done <- m.Wait(ctx) }()
+
+	select {
+	case <-ran:
+		t.Fatal("hook ran before the context was cancelled")
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	if summary := <-done; len(summary.Results) != 1 {
+		t.Errorf("Wait() = %+v, want one result", summary)
+	}
+}
+
+func TestManager_GRPCServer(t *testing.T) {
+	m := NewManager("test-component")
+	graceful := newFakeGRPCServer(false)
+	hanging := newFakeGRPCServer(true)
+	_ = m.RegisterGRPCServer("graceful", graceful, PhaseServers, time.Second)
+	_ = m.RegisterGRPCServer("hanging", hanging, PhaseServers, 50*time.Millisecond)
+
+	summary := m.Shutdown(context.Background())
+	if graceful.forced || summary.Results[1].Forced {
+		t.Error("graceful gRPC server was force-stopped")
+	}
+	if !hanging.forced || !summary.Results[0].Forced {
+		t.Error("hanging gRPC server was not force-stopped")
+	}
+}
+
+func TestManager_HTTPServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to create listener: %v", err)
+	}
+	server := &http.Server{Handler: http.NewServeMux()}
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+
+	m := NewManager("test-component")
+	if err := m.RegisterHTTPServer("http", server, PhaseServers, time.Second); err != nil {
+		t.Fatalf("RegisterHTTPServer() unexpected error: %v", err)
+	}
+	if summary := m.Shutdown(context.Background()); summary.Err() != nil {
+		t.Fatalf("Shutdown() unexpected error: %v", summary.Err())
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		t.Errorf("Serve() = %v, want ErrServerClosed", err)
+	}
+}
+
+func TestShutdownGRPCServerWithTimeout(t *testing.T) {
+	if !ShutdownGRPCServerWithTimeout(newFakeGRPCServer(false), "test-component", time.Second) {
+		t.Error("ShutdownGRPCServerWithTimeout() forced a server that stopped gracefully")
+	}
+	hanging := newFakeGRPCServer(true)
+	if ShutdownGRPCServerWithTimeout(hanging, "test-component", 50*time.Millisecond) || !hanging.forced {
+		t.Error("ShutdownGRPCServerWithTimeout() did not force-stop a hanging server")
+	}
+	// A negative timeout means the default, not an immediate force-stop
+	slow := newFakeGRPCServer(true)
+	time.AfterFunc(20*time.Millisecond, func() { slow.once.Do(func() { close(slow.stopped) }) })
+	if !ShutdownGRPCServerWithTimeout(slow, "test-component", -time.Second) || slow.forced {
+		t.Error("ShutdownGRPCServerWithTimeout() with a negative timeout forced a server that stopped gracefully")
+	}
+}
@@ -103,7 +103,8 @@ type GRPCServer interface {
 }
 
 // ShutdownGRPCServer gracefully shuts down a gRPC server.
-// GracefulStop() blocks until all RPCs are finished.
+// GracefulStop() blocks until all RPCs are finished; if they have not finished
+// within DefaultShutdownTimeout, the server is force-stopped with Stop().
 //
 // Usage:
 //
@@ -113,16 +114,36 @@ type GRPCServer interface {
 //	<-shutdownCtx.Done()
 //	lifecycle.ShutdownGRPCServer(grpcServer, "my-component")
 func ShutdownGRPCServer(server GRPCServer, component string) {
+	ShutdownGRPCServerWithTimeout(server, component, DefaultShutdownTimeout)
+}
+
+// ShutdownGRPCServerWithTimeout is ShutdownGRPCServer with a custom timeout
+// (<= 0 = use default). It returns false if the server had to be force-stopped.
+func ShutdownGRPCServerWithTimeout(server GRPCServer, component string, timeout time.Duration) bool {
 	logger := logging.NewLogger(component)
 
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
 	logger.Info("Shutting down gRPC server...",
-		logging.Operation("shutdown"))
+		logging.Operation("shutdown"),
+		logging.Duration("timeout", timeout))
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	// GracefulStop() blocks until all RPCs are finished
-	server.GracefulStop()
+	if err := gracefulStop(ctx, server); err != nil {
+		logger.Warn("gRPC server did not stop gracefully, forcing stop",
+			logging.Operation("shutdown"),
+			logging.ErrorCode("SHUTDOWN_TIMEOUT"))
+		server.Stop()
+		return false
+	}
 
 	logger.Info("gRPC server shut down gracefully",
 		logging.Operation("shutdown_complete"))
+	return true
 }
 
 // WaitForShutdown waits for a context to be cancelled and optionally runs cleanup.
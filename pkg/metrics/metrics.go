@@ -17,6 +17,7 @@ limitations under the License.
 package metrics
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -103,6 +104,23 @@ func NewRecorder(componentName string) *Recorder {
 	return recorder
 }
 
+// Register registers c with the controller-runtime metrics registry and returns
+// it. If an identical collector is already registered (e.g., by another
+// instance of the same component metrics), the existing collector is returned
+// instead, so all instances record into the same series. Other registration
+// errors are ignored, as with the metrics registered by NewRecorder.
+func Register[T prometheus.Collector](c T) T {
+	if err := metrics.Registry.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(T); ok {
+				return existing
+			}
+		}
+	}
+	return c
+}
+
 // RecordReconciliation records a reconciliation attempt
 func (r *Recorder) RecordReconciliation(result string, durationSeconds float64) {
 	r.reconciliationsTotal.WithLabelValues(result).Inc()
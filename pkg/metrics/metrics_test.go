@@ -19,6 +19,8 @@ package metrics
 import (
 	"testing"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestNewRecorder(t *testing.T) {
@@ -69,3 +71,18 @@ func TestRecordError(t *testing.T) {
 	recorder.RecordError("reconciliation")
 	recorder.RecordError("webhook")
 }
+
+func TestRegister(t *testing.T) {
+	newCounter := func() *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "zen_test_register_total",
+			Help: "Test counter for Register",
+		}, []string{"result"})
+	}
+
+	first := Register(newCounter())
+	second := Register(newCounter())
+	if first != second {
+		t.Error("Expected Register to return the already registered collector")
+	}
+}
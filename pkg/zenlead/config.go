@@ -54,7 +54,7 @@ type LeaderElectionConfig struct {
 
 	// Namespace is the namespace where the Lease resource is created.
 	// Required for BuiltIn and ZenLeadManaged modes.
-	// Typically obtained via RequirePodNamespace().
+	// Typically obtained via PodNamespace().
 	Namespace string
 
 	// LeaseName is the name of the LeaderGroup CRD (only for ZenLeadManaged mode).
@@ -62,16 +62,27 @@ type LeaderElectionConfig struct {
 	// zen-lead will create a Lease with deterministic name derived from this.
 	LeaseName string
 
+	// ManagedFallback decides what PreflightZenLeadManaged does if zen-lead has
+	// not provisioned the LeaderGroup and Lease (only for ZenLeadManaged mode).
+	// Default: ManagedFallbackFail
+	ManagedFallback ManagedFallbackPolicy
+
+	// Profile fills any timing below that is not set explicitly.
+	// Optional: uses controller-runtime defaults (ProfileDefault) if not set.
+	Profile TimingProfile
+
 	// LeaseDuration is how long a leader holds the lease before it expires.
-	// Optional: uses controller-runtime defaults if not set.
+	// Optional: uses Profile or controller-runtime defaults if not set.
+	// Must be greater than RenewDeadline.
 	LeaseDuration *time.Duration
 
 	// RenewDeadline is the time to renew the lease before losing leadership.
-	// Optional: uses controller-runtime defaults if not set.
+	// Optional: uses Profile or controller-runtime defaults if not set.
+	// Must be greater than RetryPeriod × JitterFactor.
 	RenewDeadline *time.Duration
 
 	// RetryPeriod is how often to retry acquiring leadership.
-	// Optional: uses controller-runtime defaults if not set.
+	// Optional: uses Profile or controller-runtime defaults if not set.
 	RetryPeriod *time.Duration
 }
 
@@ -104,10 +115,18 @@ func ControllerRuntimeDefaults(cfg *rest.Config) {
 //   - error if configuration is invalid
 func PrepareManagerOptions(base *ctrl.Options, le *LeaderElectionConfig) (ctrl.Options, error) {
 	opts := *base
+	if err := ApplyConfig(&opts, le); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
 
+// ApplyConfig validates le and writes its leader election settings into opts.
+// opts is left unchanged if le is invalid.
+func ApplyConfig(opts *ctrl.Options, le *LeaderElectionConfig) error {
 	// Validate configuration
 	if err := validateConfig(le); err != nil {
-		return opts, fmt.Errorf("invalid leader election config: %w", err)
+		return fmt.Errorf("invalid leader election config: %w", err)
 	}
 
 	// Configure based on mode
@@ -131,18 +150,19 @@ func PrepareManagerOptions(base *ctrl.Options, le *LeaderElectionConfig) (ctrl.O
 		// No other settings needed
 	}
 
-	// Apply optional timing overrides
-	if le.LeaseDuration != nil {
-		opts.LeaseDuration = le.LeaseDuration
-	}
-	if le.RenewDeadline != nil {
-		opts.RenewDeadline = le.RenewDeadline
-	}
-	if le.RetryPeriod != nil {
-		opts.RetryPeriod = le.RetryPeriod
+	// Apply the profile and timing overrides as resolved by EffectiveTimings;
+	// without either, controller-runtime's defaults apply
+	if le.Profile != "" || le.LeaseDuration != nil || le.RenewDeadline != nil || le.RetryPeriod != nil {
+		t, err := le.EffectiveTimings()
+		if err != nil {
+			return fmt.Errorf("invalid leader election config: %w", err)
+		}
+		opts.LeaseDuration = &t.LeaseDuration
+		opts.RenewDeadline = &t.RenewDeadline
+		opts.RetryPeriod = &t.RetryPeriod
 	}
 
-	return opts, nil
+	return nil
 }
 
 // EnforceSafeHA validates that HA configuration is safe.
@@ -184,15 +204,21 @@ func validateConfig(le *LeaderElectionConfig) error {
 		if le.Namespace == "" {
 			return fmt.Errorf("Namespace is required for ZenLeadManaged mode")
 		}
+		switch le.ManagedFallback {
+		case "", ManagedFallbackFail, ManagedFallbackBuiltIn:
+		default:
+			return fmt.Errorf("invalid ManagedFallback: %q (must be fail or builtin)", le.ManagedFallback)
+		}
 
 	case Disabled:
 		// No validation needed for disabled mode
+		return nil
 
 	default:
 		return fmt.Errorf("invalid LeadershipMode: %q (must be builtin, zenlead, or disabled)", le.Mode)
 	}
 
-	return nil
+	return validateTimings(le)
 }
 
 // deriveElectionIDFromLeaseName derives a deterministic ElectionID from a LeaseName.
@@ -0,0 +1,266 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Environment variables read by LoadConfigFromEnv and used as BindFlags defaults.
+const (
+	EnvMode          = "ZEN_LEADER_MODE"             // builtin | zenlead | disabled (default: builtin)
+	EnvElectionID    = "ZEN_LEADER_ELECTION_ID"      // Lease name for BuiltIn mode
+	EnvNamespace     = "ZEN_LEADER_NAMESPACE"        // default: POD_NAMESPACE, then the service account namespace
+	EnvLeaseName     = "ZEN_LEADER_LEASE_NAME"       // LeaderGroup name for ZenLeadManaged mode
+	EnvProfile       = "ZEN_LEADER_PROFILE"          // fast-failover | default | api-friendly
+	EnvFallback      = "ZEN_LEADER_MANAGED_FALLBACK" // fail | builtin (default: fail)
+	EnvLeaseDuration = "ZEN_LEADER_LEASE_DURATION"   // e.g. "15s"
+	EnvRenewDeadline = "ZEN_LEADER_RENEW_DEADLINE"   // e.g. "10s"
+	EnvRetryPeriod   = "ZEN_LEADER_RETRY_PERIOD"     // e.g. "2s"
+)
+
+// Command-line flags registered by BindFlags.
+const (
+	FlagMode          = "leader-election-mode"
+	FlagElectionID    = "leader-election-id"
+	FlagNamespace     = "leader-election-namespace"
+	FlagLeaseName     = "leader-election-lease-name"
+	FlagProfile       = "leader-election-profile"
+	FlagFallback      = "leader-election-managed-fallback"
+	FlagLeaseDuration = "leader-election-lease-duration"
+	FlagRenewDeadline = "leader-election-renew-deadline"
+	FlagRetryPeriod   = "leader-election-retry-period"
+)
+
+// LoadConfigFromEnv builds a validated LeaderElectionConfig from the
+// ZEN_LEADER_* environment variables. Mode defaults to BuiltIn and Namespace
+// to the pod namespace.
+func LoadConfigFromEnv() (*LeaderElectionConfig, error) {
+	return LoadConfigFromEnvWithDefaults(LeaderElectionConfig{})
+}
+
+// LoadConfigFromEnvWithDefaults is LoadConfigFromEnv with component defaults
+// (e.g., ElectionID "<component>-leader-election") that the ZEN_LEADER_*
+// variables override.
+func LoadConfigFromEnvWithDefaults(defaults LeaderElectionConfig) (*LeaderElectionConfig, error) {
+	le, envErrs := configFromEnv(defaults)
+	if len(envErrs) > 0 {
+		return nil, envErrs[0].err
+	}
+	if err := validateConfig(le); err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+	return le, nil
+}
+
+// envError is an invalid ZEN_LEADER_* value and the flag that overrides it.
+type envError struct {
+	flag string
+	err  error
+}
+
+// configFromEnv overlays the ZEN_LEADER_* variables on defaults without
+// validating. Invalid durations are skipped and returned in a fixed order.
+func configFromEnv(defaults LeaderElectionConfig) (*LeaderElectionConfig, []envError) {
+	le := defaults
+	if v := os.Getenv(EnvMode); v != "" {
+		le.Mode = LeadershipMode(strings.ToLower(v))
+	}
+	if le.Mode == "" {
+		le.Mode = BuiltIn
+	}
+	if v := os.Getenv(EnvElectionID); v != "" {
+		le.ElectionID = v
+	}
+	if v := os.Getenv(EnvLeaseName); v != "" {
+		le.LeaseName = v
+	}
+	if v := os.Getenv(EnvNamespace); v != "" {
+		le.Namespace = v
+	}
+	if le.Namespace == "" {
+		le.Namespace, _ = PodNamespace()
+	}
+	if v := os.Getenv(EnvProfile); v != "" {
+		le.Profile = TimingProfile(strings.ToLower(v))
+	}
+	if v := os.Getenv(EnvFallback); v != "" {
+		le.ManagedFallback = ManagedFallbackPolicy(strings.ToLower(v))
+	}
+
+	var envErrs []envError
+	for _, d := range []struct {
+		env    string
+		flag   string
+		target **time.Duration
+	}{
+		{EnvLeaseDuration, FlagLeaseDuration, &le.LeaseDuration},
+		{EnvRenewDeadline, FlagRenewDeadline, &le.RenewDeadline},
+		{EnvRetryPeriod, FlagRetryPeriod, &le.RetryPeriod},
+	} {
+		v := os.Getenv(d.env)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 {
+			envErrs = append(envErrs, envError{
+				flag: d.flag,
+				err:  fmt.Errorf("%s must be a positive duration (e.g., 15s), got: %s", d.env, v),
+			})
+			continue
+		}
+		*d.target = &parsed
+	}
+	return &le, envErrs
+}
+
+// serviceAccountNamespaceFile is read when POD_NAMESPACE is not set.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// PodNamespace returns the pod namespace from POD_NAMESPACE (Downward API),
+// falling back to the service account namespace file. It fails if neither is set.
+func PodNamespace() (string, error) {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns, nil
+	}
+	if data, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		if ns := strings.TrimSpace(string(data)); ns != "" {
+			return ns, nil
+		}
+	}
+	return "", fmt.Errorf("POD_NAMESPACE environment variable must be set or service account namespace file must be readable")
+}
+
+// Flags holds leader election flags registered by BindFlags.
+type Flags struct {
+	mode          string
+	electionID    string
+	namespace     string
+	leaseName     string
+	profile       string
+	fallback      string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+
+	fs *pflag.FlagSet
+	// envErrs are invalid ZEN_LEADER_* values, reported by Config unless
+	// their flag is set.
+	envErrs []envError
+}
+
+// BindFlags registers the --leader-election-* flags on fs. Their defaults come
+// from the ZEN_LEADER_* environment variables, so flags override env. Call
+// Config after fs.Parse to get the validated configuration.
+func BindFlags(fs *pflag.FlagSet) *Flags {
+	return BindFlagsWithDefaults(fs, LeaderElectionConfig{})
+}
+
+// BindFlagsWithDefaults is BindFlags with component defaults that the
+// ZEN_LEADER_* variables and the flags override.
+func BindFlagsWithDefaults(fs *pflag.FlagSet, defaults LeaderElectionConfig) *Flags {
+	le, envErrs := configFromEnv(defaults)
+	f := &Flags{fs: fs, envErrs: envErrs}
+
+	fs.StringVar(&f.mode, FlagMode, string(le.Mode),
+		"Leader election mode: builtin, zenlead or disabled (env "+EnvMode+")")
+	fs.StringVar(&f.electionID, FlagElectionID, le.ElectionID,
+		"Name of the leader election Lease in builtin mode (env "+EnvElectionID+")")
+	fs.StringVar(&f.namespace, FlagNamespace, le.Namespace,
+		"Namespace of the leader election Lease (env "+EnvNamespace+", default: pod namespace)")
+	fs.StringVar(&f.leaseName, FlagLeaseName, le.LeaseName,
+		"LeaderGroup name in zenlead mode (env "+EnvLeaseName+")")
+	fs.StringVar(&f.fallback, FlagFallback, string(le.ManagedFallback),
+		"What to do in zenlead mode if zen-lead has not provisioned the LeaderGroup: fail or builtin (env "+EnvFallback+")")
+	fs.StringVar(&f.profile, FlagProfile, string(le.Profile),
+		"Leader election timing profile: fast-failover, default or api-friendly (env "+EnvProfile+")")
+	fs.DurationVar(&f.leaseDuration, FlagLeaseDuration, durationValue(le.LeaseDuration),
+		"Leader election lease duration, positive; unset uses the profile default (env "+EnvLeaseDuration+")")
+	fs.DurationVar(&f.renewDeadline, FlagRenewDeadline, durationValue(le.RenewDeadline),
+		"Leader election renew deadline, positive; unset uses the profile default (env "+EnvRenewDeadline+")")
+	fs.DurationVar(&f.retryPeriod, FlagRetryPeriod, durationValue(le.RetryPeriod),
+		"Leader election retry period, positive; unset uses the profile default (env "+EnvRetryPeriod+")")
+	return f
+}
+
+// Config returns the validated configuration from the parsed flags. An
+// invalid ZEN_LEADER_* duration is an error only if its flag is not set.
+func (f *Flags) Config() (*LeaderElectionConfig, error) {
+	for _, e := range f.envErrs {
+		if !f.fs.Changed(e.flag) {
+			return nil, e.err
+		}
+	}
+	leaseDuration, err := f.duration(FlagLeaseDuration, f.leaseDuration)
+	if err != nil {
+		return nil, err
+	}
+	renewDeadline, err := f.duration(FlagRenewDeadline, f.renewDeadline)
+	if err != nil {
+		return nil, err
+	}
+	retryPeriod, err := f.duration(FlagRetryPeriod, f.retryPeriod)
+	if err != nil {
+		return nil, err
+	}
+	le := &LeaderElectionConfig{
+		Mode:            LeadershipMode(strings.ToLower(f.mode)),
+		ElectionID:      f.electionID,
+		Namespace:       f.namespace,
+		LeaseName:       f.leaseName,
+		Profile:         TimingProfile(strings.ToLower(f.profile)),
+		ManagedFallback: ManagedFallbackPolicy(strings.ToLower(f.fallback)),
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+	}
+	if err := validateConfig(le); err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+	return le, nil
+}
+
+// duration returns the value of the duration flag name, or nil if it is unset.
+// Like the ZEN_LEADER_* variables, a flag that is set must be positive.
+func (f *Flags) duration(name string, d time.Duration) (*time.Duration, error) {
+	if f.fs.Changed(name) && d <= 0 {
+		return nil, fmt.Errorf("--%s must be a positive duration (e.g., 15s), got: %s", name, d)
+	}
+	return durationPtr(d), nil
+}
+
+// durationValue returns *d, or 0 if d is nil.
+func durationValue(d *time.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return *d
+}
+
+// durationPtr returns &d, or nil if d is not positive.
+func durationPtr(d time.Duration) *time.Duration {
+	if d <= 0 {
+		return nil
+	}
+	return &d
+}
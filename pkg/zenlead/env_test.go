@@ -0,0 +1,216 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// clearLeaderEnv unsets every ZEN_LEADER_* variable and sets POD_NAMESPACE.
+func clearLeaderEnv(t *testing.T) {
+	t.Helper()
+	for _, env := range []string{EnvMode, EnvElectionID, EnvNamespace, EnvLeaseName, EnvProfile, EnvFallback, EnvLeaseDuration, EnvRenewDeadline, EnvRetryPeriod} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("POD_NAMESPACE", "pod-namespace")
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	clearLeaderEnv(t)
+	t.Setenv(EnvElectionID, "my-controller-leader-election")
+	t.Setenv(EnvLeaseDuration, "30s")
+	t.Setenv(EnvRetryPeriod, "5s")
+
+	le, err := LoadConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadConfigFromEnv() unexpected error: %v", err)
+	}
+	if le.Mode != BuiltIn || le.ElectionID != "my-controller-leader-election" || le.Namespace != "pod-namespace" {
+		t.Errorf("LoadConfigFromEnv() = %+v", le)
+	}
+	if le.LeaseDuration == nil || *le.LeaseDuration != 30*time.Second {
+		t.Errorf("LeaseDuration = %v, want 30s", le.LeaseDuration)
+	}
+	if le.RenewDeadline != nil {
+		t.Errorf("RenewDeadline = %v, want unset", *le.RenewDeadline)
+	}
+	if le.RetryPeriod == nil || *le.RetryPeriod != 5*time.Second {
+		t.Errorf("RetryPeriod = %v, want 5s", le.RetryPeriod)
+	}
+}
+
+func TestLoadConfigFromEnv_ZenLeadManaged(t *testing.T) {
+	clearLeaderEnv(t)
+	t.Setenv(EnvMode, "ZenLead")
+	t.Setenv(EnvLeaseName, "my-leader-group")
+	t.Setenv(EnvNamespace, "zen-system")
+
+	le, err := LoadConfigFromEnv()
+	if err != nil {
+		t.Fatalf("LoadConfigFromEnv() unexpected error: %v", err)
+	}
+	if le.Mode != ZenLeadManaged || le.LeaseName != "my-leader-group" || le.Namespace != "zen-system" {
+		t.Errorf("LoadConfigFromEnv() = %+v", le)
+	}
+}
+
+func TestLoadConfigFromEnv_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{name: "missing election ID", wantErr: "ElectionID is required"},
+		{name: "invalid mode", env: map[string]string{EnvMode: "active-passive"}, wantErr: "invalid LeadershipMode"},
+		{
+			name:    "invalid duration",
+			env:     map[string]string{EnvElectionID: "id", EnvRenewDeadline: "ten seconds"},
+			wantErr: EnvRenewDeadline,
+		},
+		{
+			name:    "negative duration",
+			env:     map[string]string{EnvElectionID: "id", EnvLeaseDuration: "-15s"},
+			wantErr: EnvLeaseDuration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearLeaderEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := LoadConfigFromEnv()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfigFromEnv() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFromEnvWithDefaults(t *testing.T) {
+	clearLeaderEnv(t)
+	defaults := LeaderElectionConfig{ElectionID: "zen-gc-leader-election"}
+
+	le, err := LoadConfigFromEnvWithDefaults(defaults)
+	if err != nil || le.ElectionID != "zen-gc-leader-election" {
+		t.Fatalf("defaults: %+v, %v", le, err)
+	}
+
+	t.Setenv(EnvElectionID, "custom-id")
+	t.Setenv(EnvMode, "disabled")
+	le, err = LoadConfigFromEnvWithDefaults(defaults)
+	if err != nil || le.ElectionID != "custom-id" || le.Mode != Disabled {
+		t.Errorf("env overrides: %+v, %v", le, err)
+	}
+}
+
+func TestBindFlags(t *testing.T) {
+	clearLeaderEnv(t)
+	t.Setenv(EnvElectionID, "from-env")
+	t.Setenv(EnvLeaseDuration, "20s")
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags := BindFlags(fs)
+	if err := fs.Parse([]string{"--" + FlagNamespace + "=from-flag", "--" + FlagRenewDeadline + "=8s"}); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	le, err := flags.Config()
+	if err != nil {
+		t.Fatalf("Config() unexpected error: %v", err)
+	}
+	if le.Mode != BuiltIn || le.ElectionID != "from-env" || le.Namespace != "from-flag" {
+		t.Errorf("Config() = %+v", le)
+	}
+	if le.LeaseDuration == nil || *le.LeaseDuration != 20*time.Second {
+		t.Errorf("LeaseDuration = %v, want 20s from env", le.LeaseDuration)
+	}
+	if le.RenewDeadline == nil || *le.RenewDeadline != 8*time.Second {
+		t.Errorf("RenewDeadline = %v, want 8s from flag", le.RenewDeadline)
+	}
+	if le.RetryPeriod != nil {
+		t.Errorf("RetryPeriod = %v, want unset", *le.RetryPeriod)
+	}
+}
+
+func TestBindFlags_Errors(t *testing.T) {
+	clearLeaderEnv(t)
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags := BindFlags(fs)
+	if err := fs.Parse([]string{"--" + FlagMode + "=zenlead"}); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if _, err := flags.Config(); err == nil || !strings.Contains(err.Error(), "LeaseName is required") {
+		t.Errorf("Config() error = %v, want LeaseName required", err)
+	}
+
+	for _, v := range []string{"-5s", "0s"} {
+		fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags = BindFlags(fs)
+		if err := fs.Parse([]string{"--" + FlagLeaseDuration + "=" + v}); err != nil {
+			t.Fatalf("Parse() unexpected error: %v", err)
+		}
+		if _, err := flags.Config(); err == nil || !strings.Contains(err.Error(), FlagLeaseDuration) {
+			t.Errorf("Config() with %s error = %v, want invalid --%s", v, err, FlagLeaseDuration)
+		}
+	}
+
+	t.Setenv(EnvRetryPeriod, "soon")
+	flags = BindFlags(pflag.NewFlagSet("test", pflag.ContinueOnError))
+	if _, err := flags.Config(); err == nil || !strings.Contains(err.Error(), EnvRetryPeriod) {
+		t.Errorf("Config() error = %v, want invalid %s", err, EnvRetryPeriod)
+	}
+
+	// A flag overrides an invalid env value
+	fs = pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags = BindFlags(fs)
+	if err := fs.Parse([]string{"--" + FlagElectionID + "=id", "--" + FlagRetryPeriod + "=3s"}); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	le, err := flags.Config()
+	if err != nil || le.RetryPeriod == nil || *le.RetryPeriod != 3*time.Second {
+		t.Errorf("Config() with --%s = %+v, %v, want 3s from flag", FlagRetryPeriod, le, err)
+	}
+}
+
+func TestLoadConfigFromEnv_Profile(t *testing.T) {
+	clearLeaderEnv(t)
+	t.Setenv(EnvElectionID, "id")
+	t.Setenv(EnvProfile, "API-Friendly")
+
+	le, err := LoadConfigFromEnv()
+	if err != nil || le.Profile != ProfileAPIFriendly {
+		t.Fatalf("LoadConfigFromEnv() = %+v, %v, want api-friendly profile", le, err)
+	}
+
+	t.Setenv(EnvRenewDeadline, "90s")
+	if _, err := LoadConfigFromEnv(); err == nil || !strings.Contains(err.Error(), "LeaseDuration 1m0s (profile default) must be greater than RenewDeadline 1m30s") {
+		t.Errorf("LoadConfigFromEnv() error = %v, want timing error", err)
+	}
+}
+
+func TestPodNamespace(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "pod-namespace")
+	if ns, err := PodNamespace(); ns != "pod-namespace" || err != nil {
+		t.Errorf("PodNamespace() = %q, %v; want pod-namespace, nil", ns, err)
+	}
+}
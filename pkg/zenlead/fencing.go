@@ -0,0 +1,250 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FencingTokenAnnotation is the annotation StampFencingToken writes.
+const FencingTokenAnnotation = "leadership.kube-zen.io/fencing-token"
+
+var (
+	// ErrNotLeader is returned when a fenced operation is started by an
+	// instance that does not hold the Lease.
+	ErrNotLeader = errors.New("not the leader")
+
+	// ErrLeadershipLost is the cause of a FencedContext's cancellation when
+	// leadership is lost (see context.Cause).
+	ErrLeadershipLost = errors.New("leadership lost")
+)
+
+// FencingToken identifies one leadership term. Lease.spec.leaseTransitions is
+// incremented every time the Lease changes hands, and restarts at 0 when the
+// Lease is deleted and recreated; the Lease's creationTimestamp orders those
+// incarnations. Tokens of later terms compare greater, so a downstream system
+// that remembers the highest token it has seen can reject writes from a stale
+// leader.
+//
+// creationTimestamp has one-second precision: terms of a Lease recreated
+// within the same second as its predecessor cannot be told apart.
+type FencingToken struct {
+	// Epoch is the Lease's creationTimestamp in Unix seconds (0 if unknown).
+	Epoch int64
+
+	// Transitions is the Lease's spec.leaseTransitions for this term.
+	Transitions int32
+
+	// Holder is the holder identity for this term.
+	Holder string
+}
+
+// fencingTokenFor returns the token of lease's current term.
+func fencingTokenFor(lease *coordinationv1.Lease) FencingToken {
+	token := FencingToken{}
+	if !lease.CreationTimestamp.IsZero() {
+		token.Epoch = lease.CreationTimestamp.Unix()
+	}
+	if lease.Spec.LeaseTransitions != nil {
+		token.Transitions = *lease.Spec.LeaseTransitions
+	}
+	if lease.Spec.HolderIdentity != nil {
+		token.Holder = *lease.Spec.HolderIdentity
+	}
+	return token
+}
+
+// IsZero reports whether t is the zero token.
+func (t FencingToken) IsZero() bool {
+	return t == FencingToken{}
+}
+
+// Compare returns -1, 0 or +1 as t belongs to an earlier, the same or a later
+// term than other. Tokens of the same term with different holders compare
+// equal; use == to check for an identical token.
+func (t FencingToken) Compare(other FencingToken) int {
+	switch {
+	case t.Epoch < other.Epoch:
+		return -1
+	case t.Epoch > other.Epoch:
+		return 1
+	case t.Transitions < other.Transitions:
+		return -1
+	case t.Transitions > other.Transitions:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String formats t as "<epoch>.<transitions>:<holder>", the format parsed by ParseFencingToken.
+func (t FencingToken) String() string {
+	return fmt.Sprintf("%d.%d:%s", t.Epoch, t.Transitions, t.Holder)
+}
+
+// ParseFencingToken parses a token formatted by FencingToken.String. Tokens
+// without an epoch ("<transitions>:<holder>") parse with Epoch 0.
+func ParseFencingToken(s string) (FencingToken, error) {
+	term, holder, ok := strings.Cut(s, ":")
+	if !ok || holder == "" {
+		return FencingToken{}, fmt.Errorf("invalid fencing token %q: want <epoch>.<transitions>:<holder>", s)
+	}
+	token := FencingToken{Holder: holder}
+	if epoch, transitions, ok := strings.Cut(term, "."); ok {
+		n, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil || n < 0 {
+			return FencingToken{}, fmt.Errorf("invalid fencing token %q: epoch must be a non-negative integer", s)
+		}
+		token.Epoch = n
+		term = transitions
+	}
+	n, err := strconv.ParseInt(term, 10, 32)
+	if err != nil || n < 0 {
+		return FencingToken{}, fmt.Errorf("invalid fencing token %q: transitions must be a non-negative integer", s)
+	}
+	token.Transitions = int32(n)
+	return token, nil
+}
+
+// FencingToken returns the token of the current term if this instance is the leader.
+func (o *Observer) FencingToken() (FencingToken, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if !o.isLeader || o.lease == nil {
+		return FencingToken{}, false
+	}
+	return fencingTokenFor(o.lease), true
+}
+
+// fence is a FencedContext waiting for its term to end.
+type fence struct {
+	token  FencingToken
+	cancel context.CancelCauseFunc
+}
+
+// FencedContext returns a context carrying the current fencing token that is
+// canceled, with cause ErrLeadershipLost, as soon as the Observer sees this
+// instance lose leadership or the Lease move to a new term (at most
+// PollInterval after it happens, or when Run returns). Use it for
+// side-effecting work that must not outlive the leadership it started under.
+// It returns ErrNotLeader if this instance is not currently the leader.
+//
+// The returned CancelFunc must be called when the work is done.
+func (o *Observer) FencedContext(parent context.Context) (context.Context, context.CancelFunc, error) {
+	o.eventMu.Lock()
+	defer o.eventMu.Unlock()
+
+	token, ok := o.FencingToken()
+	if !ok {
+		return nil, nil, ErrNotLeader
+	}
+
+	ctx, cancel := context.WithCancelCause(context.WithValue(parent, fencingTokenKey{}, token))
+	f := &fence{token: token, cancel: cancel}
+
+	o.fencesMu.Lock()
+	if o.fences == nil {
+		o.fences = make(map[*fence]struct{})
+	}
+	o.fences[f] = struct{}{}
+	o.fencesMu.Unlock()
+
+	return ctx, func() {
+		o.fencesMu.Lock()
+		delete(o.fences, f)
+		o.fencesMu.Unlock()
+		cancel(context.Canceled)
+	}, nil
+}
+
+// releaseFences cancels fences whose term is no longer current. Caller must hold o.eventMu.
+func (o *Observer) releaseFences() {
+	current, isLeader := o.FencingToken()
+
+	o.fencesMu.Lock()
+	defer o.fencesMu.Unlock()
+	for f := range o.fences {
+		if isLeader && f.token == current {
+			continue
+		}
+		f.cancel(ErrLeadershipLost)
+		delete(o.fences, f)
+	}
+}
+
+// fencingTokenKey is the context key for the fencing token.
+type fencingTokenKey struct{}
+
+// FencingTokenFromContext returns the fencing token carried by a FencedContext.
+func FencingTokenFromContext(ctx context.Context) (FencingToken, bool) {
+	token, ok := ctx.Value(fencingTokenKey{}).(FencingToken)
+	return token, ok
+}
+
+// StampFencingToken sets FencingTokenAnnotation on obj to token.
+func StampFencingToken(obj metav1.Object, token FencingToken) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[FencingTokenAnnotation] = token.String()
+	obj.SetAnnotations(annotations)
+}
+
+// StampFencingTokenFromContext stamps obj with the token of the FencedContext
+// ctx. It fails if ctx carries no token or has already been canceled (e.g.,
+// because leadership was lost), so a stale leader never stamps a write.
+func StampFencingTokenFromContext(ctx context.Context, obj metav1.Object) error {
+	token, ok := FencingTokenFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("context carries no fencing token: use Observer.FencedContext")
+	}
+	if err := context.Cause(ctx); err != nil {
+		return fmt.Errorf("fenced context is done: %w", err)
+	}
+	StampFencingToken(obj, token)
+	return nil
+}
+
+// FencingTokenFromObject returns the token stamped on obj, if any.
+func FencingTokenFromObject(obj metav1.Object) (FencingToken, bool, error) {
+	value, ok := obj.GetAnnotations()[FencingTokenAnnotation]
+	if !ok {
+		return FencingToken{}, false, nil
+	}
+	token, err := ParseFencingToken(value)
+	if err != nil {
+		return FencingToken{}, true, err
+	}
+	return token, true, nil
+}
+
+// CheckFencingToken is the downstream check: it returns an error if token
+// belongs to an earlier term than latest, the highest token seen so far.
+func CheckFencingToken(token, latest FencingToken) error {
+	if token.Compare(latest) < 0 {
+		return fmt.Errorf("stale fencing token %s: latest is %s", token, latest)
+	}
+	return nil
+}
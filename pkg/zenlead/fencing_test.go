@@ -0,0 +1,217 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newTermLease returns a Lease created at observerNow, held by holder in the given term.
+func newTermLease(holder string, transitions int32, renewed time.Time) *coordinationv1.Lease {
+	lease := newLease("test-controller-leader-election", holder, renewed)
+	lease.CreationTimestamp = metav1.NewTime(observerNow)
+	lease.Spec.LeaseTransitions = &transitions
+	return lease
+}
+
+func TestFencingToken_StringAndParse(t *testing.T) {
+	token := FencingToken{Epoch: 1735732800, Transitions: 7, Holder: "pod-a_1234"}
+	if got := token.String(); got != "1735732800.7:pod-a_1234" {
+		t.Errorf("String() = %q, want %q", got, "1735732800.7:pod-a_1234")
+	}
+
+	parsed, err := ParseFencingToken(token.String())
+	if err != nil || parsed != token {
+		t.Errorf("ParseFencingToken() = %+v, %v; want %+v", parsed, err, token)
+	}
+
+	// Tokens stamped without an epoch
+	legacy := FencingToken{Transitions: 7, Holder: "pod-a_1234"}
+	if parsed, err := ParseFencingToken("7:pod-a_1234"); err != nil || parsed != legacy {
+		t.Errorf("ParseFencingToken() = %+v, %v; want %+v", parsed, err, legacy)
+	}
+
+	for _, invalid := range []string{"", "7", "x:pod-a", "-1:pod-a", "7:", "x.7:pod-a", "-1.7:pod-a", "1.x:pod-a"} {
+		if _, err := ParseFencingToken(invalid); err == nil {
+			t.Errorf("ParseFencingToken(%q) expected error", invalid)
+		}
+	}
+}
+
+func TestCheckFencingToken(t *testing.T) {
+	older := FencingToken{Transitions: 3, Holder: "pod-a"}
+	newer := FencingToken{Transitions: 4, Holder: "pod-b"}
+
+	if err := CheckFencingToken(older, newer); err == nil {
+		t.Error("CheckFencingToken() accepted a token from an earlier term")
+	}
+	if err := CheckFencingToken(newer, older); err != nil {
+		t.Errorf("CheckFencingToken() rejected a newer token: %v", err)
+	}
+	if err := CheckFencingToken(newer, newer); err != nil {
+		t.Errorf("CheckFencingToken() rejected the current token: %v", err)
+	}
+
+	// leaseTransitions restarts at 0 when the Lease is recreated
+	recreated := FencingToken{Epoch: 200, Transitions: 0, Holder: "pod-c"}
+	before := FencingToken{Epoch: 100, Transitions: 9, Holder: "pod-b"}
+	if err := CheckFencingToken(before, recreated); err == nil {
+		t.Error("CheckFencingToken() accepted a token from a deleted Lease")
+	}
+	if err := CheckFencingToken(recreated, before); err != nil {
+		t.Errorf("CheckFencingToken() rejected a token from the recreated Lease: %v", err)
+	}
+}
+
+func TestObserver_FencedContext(t *testing.T) {
+	now := observerNow
+	client := fake.NewClientset(newTermLease("pod-a_1", 3, now))
+	o, err := NewObserver(client, ObserverConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	token, ok := o.FencingToken()
+	if !ok || token != (FencingToken{Epoch: observerNow.Unix(), Transitions: 3, Holder: "pod-a_1"}) {
+		t.Fatalf("FencingToken() = %+v, %v", token, ok)
+	}
+
+	fenced, cancel, err := o.FencedContext(ctx)
+	if err != nil {
+		t.Fatalf("FencedContext() unexpected error: %v", err)
+	}
+	defer cancel()
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "output"}}
+	if err := StampFencingTokenFromContext(fenced, cm); err != nil {
+		t.Fatalf("StampFencingTokenFromContext() unexpected error: %v", err)
+	}
+	if got, ok, err := FencingTokenFromObject(cm); !ok || err != nil || got != token {
+		t.Errorf("FencingTokenFromObject() = %+v, %v, %v; want %+v", got, ok, err, token)
+	}
+
+	// Renewals within the same term keep the fence open
+	now = now.Add(5 * time.Second)
+	updateLease(t, client, newTermLease("pod-a_1", 3, now))
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if fenced.Err() != nil {
+		t.Fatalf("fenced context canceled on renewal: %v", context.Cause(fenced))
+	}
+
+	// Another pod takes over
+	updateLease(t, client, newTermLease("pod-b_2", 4, now))
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	select {
+	case <-fenced.Done():
+	default:
+		t.Fatal("fenced context not canceled after leadership was lost")
+	}
+	if !errors.Is(context.Cause(fenced), ErrLeadershipLost) {
+		t.Errorf("context.Cause() = %v, want ErrLeadershipLost", context.Cause(fenced))
+	}
+	if err := StampFencingTokenFromContext(fenced, &corev1.ConfigMap{}); err == nil {
+		t.Error("StampFencingTokenFromContext() stamped from a canceled fence")
+	}
+
+	if _, _, err := o.FencedContext(ctx); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("FencedContext() as follower error = %v, want ErrNotLeader", err)
+	}
+}
+
+func TestObserver_FencedContextReleasedOnStop(t *testing.T) {
+	now := observerNow
+	o, err := NewObserver(fake.NewClientset(newTermLease("pod-a", 1, now)), ObserverConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+	if err := o.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+
+	fenced, cancel, err := o.FencedContext(context.Background())
+	if err != nil {
+		t.Fatalf("FencedContext() unexpected error: %v", err)
+	}
+	defer cancel()
+
+	o.reset()
+	if !errors.Is(context.Cause(fenced), ErrLeadershipLost) {
+		t.Errorf("context.Cause() = %v, want ErrLeadershipLost after the observer stopped", context.Cause(fenced))
+	}
+}
+
+func TestObserver_FencedContextPreviousRun(t *testing.T) {
+	now := observerNow
+	// The Lease is still held by the previous run of this StatefulSet pod
+	o, err := NewObserver(fake.NewClientset(newTermLease("watcher-0_old", 1, now)), ObserverConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "watcher-0_new",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+	if err := o.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if o.IsLeader() {
+		t.Error("IsLeader() = true for a Lease held by a previous run")
+	}
+	if _, _, err := o.FencedContext(context.Background()); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("FencedContext() error = %v, want ErrNotLeader", err)
+	}
+}
+
+func TestFencingTokenFromObject(t *testing.T) {
+	cm := &corev1.ConfigMap{}
+	if _, ok, err := FencingTokenFromObject(cm); ok || err != nil {
+		t.Errorf("unstamped object: ok=%v err=%v", ok, err)
+	}
+
+	cm.Annotations = map[string]string{FencingTokenAnnotation: "garbage"}
+	if _, ok, err := FencingTokenFromObject(cm); !ok || err == nil {
+		t.Errorf("invalid annotation: ok=%v err=%v, want error", ok, err)
+	}
+
+	if err := StampFencingTokenFromContext(context.Background(), cm); err == nil {
+		t.Error("StampFencingTokenFromContext() without a fenced context expected error")
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// LeaseGuard defaults.
+const (
+	// DefaultGuardCacheTTL is how long a Lease check is reused. Keep it well
+	// below RenewDeadline so a deposed leader stops writing promptly.
+	DefaultGuardCacheTTL = 2 * time.Second
+
+	// DefaultFollowerRequeueAfter is how long followers wait before retrying a request.
+	DefaultFollowerRequeueAfter = 5 * time.Second
+)
+
+// GuardConfig holds LeaseGuard configuration.
+type GuardConfig struct {
+	// LeaderElection is the leader election configuration the manager was
+	// started with. Required. In Disabled mode (single replica) every request
+	// is passed through.
+	LeaderElection *LeaderElectionConfig
+
+	// Identity is this instance's identity (default: the hostname, i.e. the
+	// pod name), matched against the Lease holder like ObserverConfig.Identity.
+	Identity string
+
+	// CacheTTL is how long a Lease check is reused (default: DefaultGuardCacheTTL).
+	CacheTTL time.Duration
+
+	// RequeueAfter is returned to followers (default: DefaultFollowerRequeueAfter).
+	RequeueAfter time.Duration
+
+	// Now returns the current time for caching and Lease expiry (default: time.Now).
+	Now func() time.Time
+}
+
+// LeaseGuard gates individual reconcilers on holding the leader election
+// Lease, for components whose followers keep running other reconcilers (e.g.
+// serving reads) while only the leader writes. It replaces the deprecated
+// controller.LeaderGuard: leadership comes from the Lease holder identity,
+// not pod annotations, and pods are never read or mutated.
+//
+// The result of each Lease read is cached for CacheTTL, so leadership changes
+// in either direction are picked up within CacheTTL. Concurrent callers share
+// one Lease read, which is made without holding the guard's lock.
+type LeaseGuard struct {
+	client    kubernetes.Interface
+	config    GuardConfig
+	namespace string
+	leaseName string
+	disabled  bool
+	logger    *logging.Logger
+	leases    leaseTracker
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	isLeader  bool
+	lastErr   error
+	reading   chan struct{} // closed when the in-flight Lease read finishes
+}
+
+// NewLeaseGuard creates a LeaseGuard for the Lease configured in config.LeaderElection.
+func NewLeaseGuard(client kubernetes.Interface, config GuardConfig) (*LeaseGuard, error) {
+	if config.LeaderElection == nil {
+		return nil, fmt.Errorf("LeaderElection is required")
+	}
+	if err := validateConfig(config.LeaderElection); err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = DefaultGuardCacheTTL
+	}
+	if config.RequeueAfter <= 0 {
+		config.RequeueAfter = DefaultFollowerRequeueAfter
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	g := &LeaseGuard{
+		client:    client,
+		config:    config,
+		namespace: config.LeaderElection.Namespace,
+		logger:    logging.NewLogger("zenlead-guard"),
+	}
+	if config.LeaderElection.Mode == Disabled {
+		g.disabled = true
+		return g, nil
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	leaseName, err := leaseNameFor(config.LeaderElection)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := resolveIdentity(config.Identity)
+	if err != nil {
+		return nil, err
+	}
+	g.config.Identity = identity
+	g.leaseName = leaseName
+	g.logger = g.logger.WithField("lease", g.namespace+"/"+leaseName)
+	return g, nil
+}
+
+// IsLeader reports whether this instance holds the Lease, reading it at most
+// once per CacheTTL. A failed read counts as not leading and is returned.
+// Callers arriving while the Lease is being read wait for that read, or
+// return ctx's error if ctx is done first.
+func (g *LeaseGuard) IsLeader(ctx context.Context) (bool, error) {
+	if g.disabled {
+		return true, nil
+	}
+
+	g.mu.Lock()
+	for {
+		now := g.config.Now()
+		if !g.checkedAt.IsZero() && now.Sub(g.checkedAt) < g.config.CacheTTL {
+			defer g.mu.Unlock()
+			return g.isLeader, g.lastErr
+		}
+		if g.reading == nil {
+			break
+		}
+		reading := g.reading
+		g.mu.Unlock()
+		select {
+		case <-reading:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		g.mu.Lock()
+	}
+	reading := make(chan struct{})
+	g.reading = reading
+	now := g.config.Now()
+	g.mu.Unlock()
+
+	isLeader, err := g.check(ctx, now)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.reading = nil
+	close(reading)
+	if ctx.Err() != nil {
+		// Abandoned by this caller; waiters read the Lease again
+		return false, err
+	}
+
+	wasLeader := g.isLeader
+	g.isLeader, g.lastErr, g.checkedAt = isLeader, err, now
+	if g.isLeader != wasLeader {
+		g.logger.Info("Leadership changed",
+			logging.Operation("leader_guard"),
+			logging.String("identity", g.config.Identity),
+			logging.Bool("is_leader", g.isLeader))
+	}
+	return g.isLeader, g.lastErr
+}
+
+// check reads the Lease and reports whether this instance holds it.
+func (g *LeaseGuard) check(ctx context.Context, now time.Time) (bool, error) {
+	lease, err := g.client.CoordinationV1().Leases(g.namespace).Get(ctx, g.leaseName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get lease %s/%s: %w", g.namespace, g.leaseName, err)
+	}
+	holder, _ := g.leases.holder(lease, now)
+	return holder != "" && holderMatches(holder, g.config.Identity), nil
+}
+
+// Wrap returns a reconciler that calls inner only while this instance holds
+// the Lease. Followers, and requests that fail the Lease check, are requeued
+// after RequeueAfter without error.
+func (g *LeaseGuard) Wrap(inner reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		isLeader, err := g.IsLeader(ctx)
+		if err != nil {
+			g.logger.Error(err, "Failed to check leadership, treating as follower",
+				logging.Operation("leader_guard"),
+				logging.Namespace(req.Namespace),
+				logging.Name(req.Name),
+				logging.ErrorCode("LEASE_GET_ERROR"))
+		}
+		if !isLeader {
+			return reconcile.Result{RequeueAfter: g.config.RequeueAfter}, nil
+		}
+		return inner.Reconcile(ctx, req)
+	})
+}
+
+// ReadinessCheck reports whether the Lease can be read, regardless of whether
+// this instance leads, so followers serving reads stay ready. It has the
+// signature of controller-runtime's healthz.Checker:
+//
+//	mgr.AddReadyzCheck("leader-guard", guard.ReadinessCheck)
+func (g *LeaseGuard) ReadinessCheck(req *http.Request) error {
+	_, err := g.IsLeader(req.Context())
+	return err
+}
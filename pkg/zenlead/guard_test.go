@@ -0,0 +1,221 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// countingReconciler counts Reconcile calls.
+type countingReconciler struct {
+	calls int
+}
+
+func (r *countingReconciler) Reconcile(context.Context, reconcile.Request) (reconcile.Result, error) {
+	r.calls++
+	return reconcile.Result{}, nil
+}
+
+func TestLeaseGuard_Wrap(t *testing.T) {
+	now := observerNow
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a_1234", now))
+	g, err := NewLeaseGuard(client, GuardConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewLeaseGuard() unexpected error: %v", err)
+	}
+	inner := &countingReconciler{}
+	r := g.Wrap(inner)
+	ctx := context.Background()
+	req := reconcile.Request{}
+
+	if res, err := r.Reconcile(ctx, req); err != nil || res.RequeueAfter != 0 || inner.calls != 1 {
+		t.Fatalf("leader: result=%+v err=%v calls=%d, want inner called", res, err, inner.calls)
+	}
+
+	// Another pod takes over: the cached result is reused until CacheTTL passes
+	updateLease(t, client, newLease("test-controller-leader-election", "pod-b_5678", now))
+	now = now.Add(time.Second)
+	if _, err := r.Reconcile(ctx, req); err != nil || inner.calls != 2 {
+		t.Fatalf("within TTL: err=%v calls=%d, want cached leader", err, inner.calls)
+	}
+
+	now = now.Add(DefaultGuardCacheTTL)
+	res, err := r.Reconcile(ctx, req)
+	if err != nil || res.RequeueAfter != DefaultFollowerRequeueAfter || inner.calls != 2 {
+		t.Fatalf("follower: result=%+v err=%v calls=%d, want requeue", res, err, inner.calls)
+	}
+
+	// Leadership comes back: unlike the deprecated guard, the cache flips both ways
+	updateLease(t, client, newLease("test-controller-leader-election", "pod-a_9", now))
+	now = now.Add(DefaultGuardCacheTTL)
+	if _, err := r.Reconcile(ctx, req); err != nil || inner.calls != 3 {
+		t.Fatalf("re-elected: err=%v calls=%d, want inner called", err, inner.calls)
+	}
+
+	// The guard only ever reads the Lease
+	for _, action := range client.Actions() {
+		if action.GetVerb() != "get" && action.GetVerb() != "update" {
+			t.Errorf("unexpected action %s %s", action.GetVerb(), action.GetResource().Resource)
+		}
+		if action.GetResource().Resource != "leases" {
+			t.Errorf("unexpected access to %s", action.GetResource().Resource)
+		}
+	}
+}
+
+func TestLeaseGuard_ExpiredOrMissingLease(t *testing.T) {
+	now := observerNow
+	// The renewTime written by a node whose clock runs ahead is not compared
+	// with the local clock: the Lease expires once it has not changed for
+	// leaseDurationSeconds as seen by this process
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a", now.Add(time.Minute)))
+	g, err := NewLeaseGuard(client, GuardConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewLeaseGuard() unexpected error: %v", err)
+	}
+	if isLeader, err := g.IsLeader(context.Background()); !isLeader || err != nil {
+		t.Errorf("held lease: IsLeader() = %v, %v; want true, nil", isLeader, err)
+	}
+	now = now.Add(16 * time.Second)
+	if isLeader, err := g.IsLeader(context.Background()); isLeader || err != nil {
+		t.Errorf("expired lease: IsLeader() = %v, %v; want false, nil", isLeader, err)
+	}
+
+	missing, err := NewLeaseGuard(fake.NewClientset(), GuardConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewLeaseGuard() unexpected error: %v", err)
+	}
+	if isLeader, err := missing.IsLeader(context.Background()); isLeader || err != nil {
+		t.Errorf("missing lease: IsLeader() = %v, %v; want false, nil", isLeader, err)
+	}
+}
+
+func TestLeaseGuard_ReadinessCheck(t *testing.T) {
+	now := observerNow
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-b", now))
+	g, err := NewLeaseGuard(client, GuardConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewLeaseGuard() unexpected error: %v", err)
+	}
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	// Followers are ready as long as the Lease can be read
+	if err := g.ReadinessCheck(req); err != nil {
+		t.Errorf("ReadinessCheck() follower error = %v, want nil", err)
+	}
+
+	client.PrependReactor("get", "leases", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	now = now.Add(DefaultGuardCacheTTL)
+	if err := g.ReadinessCheck(req); err == nil {
+		t.Error("ReadinessCheck() expected error when the Lease cannot be read")
+	}
+
+	inner := &countingReconciler{}
+	res, err := g.Wrap(inner).Reconcile(context.Background(), reconcile.Request{})
+	if err != nil || res.RequeueAfter == 0 || inner.calls != 0 {
+		t.Errorf("Lease error: result=%+v err=%v calls=%d, want requeue without error", res, err, inner.calls)
+	}
+}
+
+func TestLeaseGuard_ConcurrentRead(t *testing.T) {
+	now := observerNow
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a", now))
+	g, err := NewLeaseGuard(client, GuardConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewLeaseGuard() unexpected error: %v", err)
+	}
+
+	var gets atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	client.PrependReactor("get", "leases", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if gets.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return false, nil, nil
+	})
+
+	first := make(chan bool)
+	go func() {
+		isLeader, _ := g.IsLeader(context.Background())
+		first <- isLeader
+	}()
+	<-started
+
+	// A caller arriving during a slow read is not blocked past its own deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if isLeader, err := g.IsLeader(ctx); isLeader || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("IsLeader() during read = %v, %v, want false, %v", isLeader, err, context.DeadlineExceeded)
+	}
+
+	second := make(chan bool)
+	go func() {
+		isLeader, _ := g.IsLeader(context.Background())
+		second <- isLeader
+	}()
+	close(release)
+	if !<-first || !<-second {
+		t.Error("IsLeader() = false, want true for both callers")
+	}
+	if n := gets.Load(); n != 1 {
+		t.Errorf("Lease reads = %d, want 1 shared read", n)
+	}
+}
+
+func TestLeaseGuard_Disabled(t *testing.T) {
+	g, err := NewLeaseGuard(nil, GuardConfig{LeaderElection: &LeaderElectionConfig{Mode: Disabled}})
+	if err != nil {
+		t.Fatalf("NewLeaseGuard() unexpected error: %v", err)
+	}
+	inner := &countingReconciler{}
+	if _, err := g.Wrap(inner).Reconcile(context.Background(), reconcile.Request{}); err != nil || inner.calls != 1 {
+		t.Errorf("disabled: err=%v calls=%d, want pass-through", err, inner.calls)
+	}
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/lifecycle"
+	"github.com/kube-zen/zen-sdk/pkg/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// DefaultDrainTimeout is how long Handoff waits for in-flight work.
+	DefaultDrainTimeout = 15 * time.Second
+
+	// DefaultStopTimeout is how long Handoff waits for the manager to stop
+	// after its context is cancelled.
+	DefaultStopTimeout = 10 * time.Second
+)
+
+// HandoffConfig holds Handoff configuration.
+type HandoffConfig struct {
+	// LeaderElection identifies the Lease to release. Required.
+	LeaderElection *LeaderElectionConfig
+
+	// Identity is this instance's leader election identity (default: hostname),
+	// matched against the Lease holder like ObserverConfig.Identity.
+	Identity string
+
+	// DrainTimeout bounds the wait for in-flight work (default: DefaultDrainTimeout).
+	DrainTimeout time.Duration
+
+	// StopTimeout bounds the wait for the manager to stop (default: DefaultStopTimeout).
+	StopTimeout time.Duration
+
+	// RequeueAfter is returned by wrapped reconcilers once draining has started
+	// (default: DefaultFollowerRequeueAfter).
+	RequeueAfter time.Duration
+}
+
+// Handoff coordinates giving up leadership on shutdown so the next leader
+// never starts while this one is still writing. When the run context is
+// cancelled (e.g. SIGTERM via lifecycle.ShutdownContext) it:
+//
+//  1. drains: wrapped reconcilers stop starting new work and requeue;
+//  2. waits for in-flight work to finish, up to DrainTimeout;
+//  3. stops the manager and waits for it to return, up to StopTimeout;
+//  4. releases the Lease if this instance still holds it.
+//
+// Each phase is logged. Run the manager through Run and wrap reconcilers
+// (or other work) with Wrap/StartWork.
+type Handoff struct {
+	client    kubernetes.Interface
+	config    HandoffConfig
+	namespace string
+	leaseName string
+	logger    *logging.Logger
+
+	mu       sync.Mutex
+	draining bool
+	inFlight int
+	drained  chan struct{}
+}
+
+// NewHandoff creates a Handoff for the Lease configured in config.LeaderElection.
+func NewHandoff(client kubernetes.Interface, config HandoffConfig) (*Handoff, error) {
+	if config.LeaderElection == nil {
+		return nil, fmt.Errorf("LeaderElection is required")
+	}
+	if err := validateConfig(config.LeaderElection); err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+	if config.DrainTimeout <= 0 {
+		config.DrainTimeout = DefaultDrainTimeout
+	}
+	if config.StopTimeout <= 0 {
+		config.StopTimeout = DefaultStopTimeout
+	}
+	if config.RequeueAfter <= 0 {
+		config.RequeueAfter = DefaultFollowerRequeueAfter
+	}
+
+	h := &Handoff{
+		client:    client,
+		config:    config,
+		namespace: config.LeaderElection.Namespace,
+		logger:    logging.NewLogger("zenlead-handoff"),
+		drained:   make(chan struct{}),
+	}
+	if config.LeaderElection.Mode == Disabled {
+		return h, nil
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	leaseName, err := leaseNameFor(config.LeaderElection)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := resolveIdentity(config.Identity)
+	if err != nil {
+		return nil, err
+	}
+	h.config.Identity = identity
+	h.leaseName = leaseName
+	h.logger = h.logger.WithField("lease", h.namespace+"/"+leaseName)
+	return h, nil
+}
+
+// StartWork registers a unit of in-flight work. It returns ok=false once
+// draining has started; otherwise done must be called when the work finishes.
+func (h *Handoff) StartWork() (done func(), ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.draining {
+		return nil, false
+	}
+	h.inFlight++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			h.inFlight--
+			if h.draining && h.inFlight == 0 {
+				close(h.drained)
+			}
+		})
+	}, true
+}
+
+// Wrap tracks inner's reconciles as in-flight work. Once draining has started,
+// new requests are requeued after RequeueAfter instead of reconciled.
+func (h *Handoff) Wrap(inner reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		done, ok := h.StartWork()
+		if !ok {
+			return reconcile.Result{RequeueAfter: h.config.RequeueAfter}, nil
+		}
+		defer done()
+		return inner.Reconcile(ctx, req)
+	})
+}
+
+// Draining reports whether shutdown has started.
+func (h *Handoff) Draining() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.draining
+}
+
+// InFlight returns the number of in-flight units of work.
+func (h *Handoff) InFlight() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.inFlight
+}
+
+// Run calls start (typically mgr.Start) with a context that stays alive until
+// in-flight work has drained after ctx is cancelled, then hands off leadership
+// as described on Handoff. It returns start's error, or an error if the
+// manager did not stop within StopTimeout.
+func (h *Handoff) Run(ctx context.Context, start func(context.Context) error) error {
+	runCtx, cancelRun := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRun()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- start(runCtx)
+	}()
+
+	select {
+	case err := <-errCh:
+		// The manager stopped on its own: nothing to drain, but still make
+		// sure the Lease does not linger until it expires.
+		h.beginDrain()
+		h.release()
+		return err
+	case <-ctx.Done():
+	}
+
+	began := time.Now()
+	inFlight := h.beginDrain()
+	h.logger.Info("Leadership handoff: draining, no new work accepted",
+		logging.Operation("leader_handoff"),
+		logging.String("phase", "drain"),
+		logging.Int("in_flight", inFlight))
+
+	timer := time.NewTimer(h.config.DrainTimeout)
+	defer timer.Stop()
+	select {
+	case <-h.drained:
+		h.logger.Info("Leadership handoff: in-flight work finished",
+			logging.Operation("leader_handoff"),
+			logging.String("phase", "wait"),
+			logging.Duration("elapsed", time.Since(began)))
+	case <-timer.C:
+		h.logger.Warn("Leadership handoff: drain timeout reached, stopping with work in flight",
+			logging.Operation("leader_handoff"),
+			logging.String("phase", "wait"),
+			logging.Int("in_flight", h.InFlight()),
+			logging.Duration("timeout", h.config.DrainTimeout))
+	}
+
+	h.logger.Info("Leadership handoff: stopping manager",
+		logging.Operation("leader_handoff"),
+		logging.String("phase", "stop"))
+	cancelRun()
+
+	var startErr error
+	stopTimer := time.NewTimer(h.config.StopTimeout)
+	defer stopTimer.Stop()
+	select {
+	case startErr = <-errCh:
+	case <-stopTimer.C:
+		// The elector may still be renewing: releasing now could let it
+		// re-acquire, so leave the Lease to expire instead.
+		err := fmt.Errorf("manager did not stop within %s, Lease left to expire", h.config.StopTimeout)
+		h.logger.Error(err, "Leadership handoff: manager stop timed out",
+			logging.Operation("leader_handoff"),
+			logging.String("phase", "stop"),
+			logging.ErrorCode("HANDOFF_STOP_TIMEOUT"))
+		return err
+	}
+
+	h.release()
+	h.logger.Info("Leadership handoff complete",
+		logging.Operation("leader_handoff"),
+		logging.String("phase", "done"),
+		logging.Duration("elapsed", time.Since(began)))
+	return startErr
+}
+
+// RunWithSignals is Run with a lifecycle.ShutdownContext, so SIGINT/SIGTERM
+// start the handoff.
+func (h *Handoff) RunWithSignals(component string, start func(context.Context) error) error {
+	ctx, cancel := lifecycle.ShutdownContext(context.Background(), component)
+	defer cancel()
+	return h.Run(ctx, start)
+}
+
+// beginDrain stops accepting new work and returns the in-flight count.
+func (h *Handoff) beginDrain() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.draining {
+		h.draining = true
+		if h.inFlight == 0 {
+			close(h.drained)
+		}
+	}
+	return h.inFlight
+}
+
+// release clears the Lease holder if this instance still holds it, the same
+// way client-go does with ReleaseOnCancel, so the next leader need not wait
+// for the Lease to expire.
+func (h *Handoff) release() {
+	if h.leaseName == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), h.config.StopTimeout)
+	defer cancel()
+
+	leases := h.client.CoordinationV1().Leases(h.namespace)
+	lease, err := leases.Get(ctx, h.leaseName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		h.logger.Error(err, "Leadership handoff: failed to get Lease",
+			logging.Operation("leader_handoff"),
+			logging.String("phase", "release"),
+			logging.ErrorCode("LEASE_GET_ERROR"))
+		return
+	}
+
+	holder := ""
+	if lease.Spec.HolderIdentity != nil {
+		holder = *lease.Spec.HolderIdentity
+	}
+	if holder == "" || !holderMatches(holder, h.config.Identity) {
+		h.logger.Info("Leadership handoff: Lease not held, nothing to release",
+			logging.Operation("leader_handoff"),
+			logging.String("phase", "release"),
+			logging.String("holder", holder))
+		return
+	}
+
+	now := metav1.NewMicroTime(time.Now())
+	released := int32(1)
+	empty := ""
+	lease.Spec.HolderIdentity = &empty
+	lease.Spec.LeaseDurationSeconds = &released
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+	if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		h.logger.Error(err, "Leadership handoff: failed to release Lease",
+			logging.Operation("leader_handoff"),
+			logging.String("phase", "release"),
+			logging.ErrorCode("LEASE_RELEASE_ERROR"))
+		return
+	}
+	h.logger.Info("Leadership handoff: Lease released",
+		logging.Operation("leader_handoff"),
+		logging.String("phase", "release"),
+		logging.String("holder", holder))
+}
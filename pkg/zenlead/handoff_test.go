@@ -0,0 +1,227 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// blockingReconciler blocks each Reconcile until release is closed.
+type blockingReconciler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingReconciler) Reconcile(context.Context, reconcile.Request) (reconcile.Result, error) {
+	r.started <- struct{}{}
+	<-r.release
+	return reconcile.Result{}, nil
+}
+
+// leaseHolderIdentity returns the holder of the test Lease.
+func leaseHolderIdentity(t *testing.T, client *fake.Clientset) string {
+	t.Helper()
+	lease, err := client.CoordinationV1().Leases("test-namespace").Get(context.Background(), "test-controller-leader-election", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get lease: %v", err)
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+func TestHandoff_DrainsBeforeRelease(t *testing.T) {
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a_1234", time.Now()))
+	h, err := NewHandoff(client, HandoffConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		DrainTimeout:   5 * time.Second,
+		StopTimeout:    5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewHandoff() unexpected error: %v", err)
+	}
+	inner := &blockingReconciler{started: make(chan struct{}), release: make(chan struct{})}
+	wrapped := h.Wrap(inner)
+
+	inFlightAtStop := make(chan int, 1)
+	start := func(ctx context.Context) error {
+		<-ctx.Done()
+		inFlightAtStop <- h.InFlight()
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- h.Run(ctx, start) }()
+
+	reconciled := make(chan struct{})
+	go func() {
+		_, _ = wrapped.Reconcile(context.Background(), reconcile.Request{})
+		close(reconciled)
+	}()
+	<-inner.started
+	cancel()
+
+	waitFor(t, "draining to start", h.Draining)
+	result, err := wrapped.Reconcile(context.Background(), reconcile.Request{})
+	if err != nil || result.RequeueAfter != DefaultFollowerRequeueAfter {
+		t.Errorf("Reconcile() while draining = %+v, %v, want requeue", result, err)
+	}
+	select {
+	case <-inFlightAtStop:
+		t.Fatal("manager stopped while a reconcile was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if holder := leaseHolderIdentity(t, client); holder != "pod-a_1234" {
+		t.Errorf("Lease released with work in flight, holder = %q", holder)
+	}
+
+	close(inner.release)
+	<-reconciled
+	if err := <-runErr; err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if n := <-inFlightAtStop; n != 0 {
+		t.Errorf("in flight when the manager stopped = %d, want 0", n)
+	}
+	if holder := leaseHolderIdentity(t, client); holder != "" {
+		t.Errorf("Lease holder after handoff = %q, want released", holder)
+	}
+}
+
+func TestHandoff_DrainTimeout(t *testing.T) {
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a", time.Now()))
+	h, err := NewHandoff(client, HandoffConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		DrainTimeout:   50 * time.Millisecond,
+		StopTimeout:    5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewHandoff() unexpected error: %v", err)
+	}
+	done, ok := h.StartWork()
+	if !ok {
+		t.Fatal("StartWork() rejected work before shutdown")
+	}
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = h.Run(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if holder := leaseHolderIdentity(t, client); holder != "" {
+		t.Errorf("Lease holder after drain timeout = %q, want released", holder)
+	}
+	if _, ok := h.StartWork(); ok {
+		t.Error("StartWork() accepted work after shutdown")
+	}
+}
+
+func TestHandoff_StopTimeoutKeepsLease(t *testing.T) {
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a", time.Now()))
+	h, err := NewHandoff(client, HandoffConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		DrainTimeout:   5 * time.Second,
+		StopTimeout:    50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewHandoff() unexpected error: %v", err)
+	}
+	hung := make(chan struct{})
+	defer close(hung)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = h.Run(ctx, func(context.Context) error {
+		<-hung
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Run() expected an error when the manager does not stop")
+	}
+	if holder := leaseHolderIdentity(t, client); holder != "pod-a" {
+		t.Errorf("Lease holder = %q, want it kept while the manager may still renew", holder)
+	}
+}
+
+func TestHandoff_ManagerExits(t *testing.T) {
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a", time.Now()))
+	h, err := NewHandoff(client, HandoffConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		DrainTimeout:   5 * time.Second,
+		StopTimeout:    5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewHandoff() unexpected error: %v", err)
+	}
+	wantErr := errors.New("cache sync failed")
+
+	if err := h.Run(context.Background(), func(context.Context) error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("Run() error = %v, want %v", err, wantErr)
+	}
+	if holder := leaseHolderIdentity(t, client); holder != "" {
+		t.Errorf("Lease holder = %q, want released", holder)
+	}
+}
+
+func TestHandoff_LeaseHeldByOther(t *testing.T) {
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-b_5678", time.Now()))
+	h, err := NewHandoff(client, HandoffConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		DrainTimeout:   5 * time.Second,
+		StopTimeout:    5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("NewHandoff() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := h.Run(ctx, func(ctx context.Context) error { <-ctx.Done(); return nil }); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if holder := leaseHolderIdentity(t, client); holder != "pod-b_5678" {
+		t.Errorf("Lease holder = %q, want another replica's Lease untouched", holder)
+	}
+}
+
+func TestHandoff_Disabled(t *testing.T) {
+	h, err := NewHandoff(nil, HandoffConfig{LeaderElection: &LeaderElectionConfig{Mode: Disabled}})
+	if err != nil {
+		t.Fatalf("NewHandoff() unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := h.Run(ctx, func(ctx context.Context) error { <-ctx.Done(); return nil }); err != nil {
+		t.Errorf("Run() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// processIdentity is generated once per process.
+var processIdentity = sync.OnceValues(func() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "", fmt.Errorf("cannot determine the hostname for the leader election identity: %v", err)
+	}
+	return hostname + "_" + string(uuid.NewUUID()), nil
+})
+
+// ProcessIdentity returns this process's leader election identity,
+// "<hostname>_<uuid>", in the format controller-runtime writes to the Lease.
+// The UUID is generated once per process, so a restarted pod that keeps its
+// hostname (e.g., a StatefulSet pod) gets a new identity.
+func ProcessIdentity() (string, error) {
+	return processIdentity()
+}
+
+// NewResourceLock returns a Lease lock for le that records ProcessIdentity as
+// the holder. Set it as ctrl.Options.LeaderElectionResourceLockInterface so an
+// Observer, LeaseGuard or Handoff configured with Identity: ProcessIdentity()
+// recognizes exactly this process as the leader, not an earlier run of the pod:
+//
+//	lock, err := zenlead.NewResourceLock(restConfig, le)
+//	opts.LeaderElectionResourceLockInterface = lock
+func NewResourceLock(restConfig *rest.Config, le *LeaderElectionConfig) (resourcelock.Interface, error) {
+	if le == nil {
+		return nil, fmt.Errorf("LeaderElection is required")
+	}
+	if err := validateConfig(le); err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+	leaseName, err := leaseNameFor(le)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := ProcessIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(rest.AddUserAgent(rest.CopyConfig(restConfig), "leader-election"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create leader election client: %w", err)
+	}
+	return &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Namespace: le.Namespace, Name: leaseName},
+		Client:     clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}, nil
+}
+
+// holderMatches reports whether a Lease holder identity belongs to identity.
+// A full "<hostname>_<suffix>" identity, such as ProcessIdentity, must match
+// exactly. A bare hostname (hostnames cannot contain "_") also matches
+// controller-runtime's "<hostname>_<uuid>" holders, including those written by
+// earlier runs of a pod that kept its hostname.
+func holderMatches(holder, identity string) bool {
+	if holder == identity {
+		return true
+	}
+	return !strings.Contains(identity, "_") && strings.HasPrefix(holder, identity+"_")
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+func TestProcessIdentity(t *testing.T) {
+	identity, err := ProcessIdentity()
+	if err != nil {
+		t.Fatalf("ProcessIdentity() unexpected error: %v", err)
+	}
+	hostname, _ := os.Hostname()
+	if !strings.HasPrefix(identity, hostname+"_") || len(identity) <= len(hostname)+1 {
+		t.Errorf("ProcessIdentity() = %q, want %q_<uuid>", identity, hostname)
+	}
+	if again, _ := ProcessIdentity(); again != identity {
+		t.Errorf("ProcessIdentity() = %q then %q, want it stable within a process", identity, again)
+	}
+}
+
+func TestHolderMatches(t *testing.T) {
+	tests := []struct {
+		holder, identity string
+		want             bool
+	}{
+		{"pod-a", "pod-a", true},
+		{"pod-a_1234", "pod-a", true},
+		{"pod-ab_1234", "pod-a", false},
+		// A full identity does not match an earlier run of the same pod
+		{"pod-a_1234", "pod-a_1234", true},
+		{"pod-a_5678", "pod-a_1234", false},
+	}
+	for _, tt := range tests {
+		if got := holderMatches(tt.holder, tt.identity); got != tt.want {
+			t.Errorf("holderMatches(%q, %q) = %v, want %v", tt.holder, tt.identity, got, tt.want)
+		}
+	}
+}
+
+func TestNewResourceLock(t *testing.T) {
+	lock, err := NewResourceLock(&rest.Config{Host: "https://127.0.0.1:6443"}, builtInConfig())
+	if err != nil {
+		t.Fatalf("NewResourceLock() unexpected error: %v", err)
+	}
+	identity, _ := ProcessIdentity()
+	lease, ok := lock.(*resourcelock.LeaseLock)
+	if !ok || lock.Identity() != identity || lease.LeaseMeta.Name != "test-controller-leader-election" || lease.LeaseMeta.Namespace != "test-namespace" {
+		t.Errorf("NewResourceLock() = %+v, want a Lease lock for the election held as %q", lock, identity)
+	}
+
+	if _, err := NewResourceLock(&rest.Config{}, &LeaderElectionConfig{Mode: Disabled}); err == nil {
+		t.Error("NewResourceLock() expected error without a Lease")
+	}
+}
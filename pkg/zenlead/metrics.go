@@ -0,0 +1,75 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"github.com/kube-zen/zen-sdk/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics receives leadership transitions from an Observer.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveTransition records a transition (TransitionStartedLeading,
+	// TransitionStoppedLeading or TransitionNewLeader) of the named Lease.
+	ObserveTransition(lease, transition string)
+
+	// SetLeader records whether this instance holds the named Lease.
+	SetLeader(lease string, isLeader bool)
+}
+
+// PrometheusMetrics exports leadership as zen_leader_transitions_total and
+// zen_leader_is_leader, labeled by Lease name.
+type PrometheusMetrics struct {
+	transitions *prometheus.CounterVec
+	isLeader    *prometheus.GaugeVec
+}
+
+// NewPrometheusMetrics creates leadership metrics and registers them with the
+// controller-runtime metrics registry. Observers may share one instance.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		transitions: metrics.Register(prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "zen_leader_transitions_total",
+				Help: "Total number of leadership transitions (transition: started_leading|stopped_leading|new_leader)",
+			},
+			[]string{"lease", "transition"},
+		)),
+		isLeader: metrics.Register(prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "zen_leader_is_leader",
+				Help: "Whether this instance holds the leader election Lease (1) or not (0)",
+			},
+			[]string{"lease"},
+		)),
+	}
+}
+
+// ObserveTransition implements Metrics.
+func (m *PrometheusMetrics) ObserveTransition(lease, transition string) {
+	m.transitions.WithLabelValues(lease, transition).Inc()
+}
+
+// SetLeader implements Metrics.
+func (m *PrometheusMetrics) SetLeader(lease string, isLeader bool) {
+	value := 0.0
+	if isLeader {
+		value = 1
+	}
+	m.isLeader.WithLabelValues(lease).Set(value)
+}
@@ -0,0 +1,446 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/logging"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultPollInterval is how often the Observer reads the Lease when neither
+// ObserverConfig.PollInterval nor LeaderElectionConfig.RetryPeriod is set.
+// It matches controller-runtime's default RetryPeriod.
+const DefaultPollInterval = 2 * time.Second
+
+// Leadership transitions reported to Metrics.
+const (
+	// TransitionStartedLeading means this instance became the leader.
+	TransitionStartedLeading = "started_leading"
+	// TransitionStoppedLeading means this instance lost leadership.
+	TransitionStoppedLeading = "stopped_leading"
+	// TransitionNewLeader means the Lease holder changed to a new identity.
+	TransitionNewLeader = "new_leader"
+)
+
+// ObserverConfig holds Observer configuration.
+type ObserverConfig struct {
+	// LeaderElection is the leader election configuration the manager was
+	// started with. Required; mode must be BuiltIn or ZenLeadManaged.
+	LeaderElection *LeaderElectionConfig
+
+	// Identity is this instance's identity (default: the hostname, i.e. the
+	// pod name). controller-runtime records holders as "<hostname>_<uuid>",
+	// so a bare hostname also matches holders written by earlier runs of this
+	// pod until their Lease expires. Use ProcessIdentity with NewResourceLock
+	// to match exactly this process.
+	Identity string
+
+	// PollInterval is how often the Lease is read
+	// (default: LeaderElection.RetryPeriod, or DefaultPollInterval).
+	PollInterval time.Duration
+
+	// Metrics optionally receives leadership transitions.
+	Metrics Metrics
+
+	// Now returns the current time for Lease expiry checks (default: time.Now).
+	Now func() time.Time
+}
+
+// Observer watches the leader election Lease and reports who holds it.
+// It only reads the Lease (it needs the same "get" permission as leader
+// election itself) and never takes part in the election, so it can run in
+// leaders and followers alike.
+//
+// A Lease whose holder and renewTime have not changed for
+// leaseDurationSeconds, as measured by this process's clock, is treated as
+// having no holder. Hooks are called sequentially from the goroutine running
+// Run and must not block.
+type Observer struct {
+	client    kubernetes.Interface
+	config    ObserverConfig
+	namespace string
+	leaseName string
+	logger    *logging.Logger
+
+	// eventMu serializes state transitions and hook calls.
+	eventMu sync.Mutex
+
+	leases leaseTracker
+
+	mu          sync.RWMutex
+	lease       *coordinationv1.Lease
+	holder      string
+	isLeader    bool
+	leaderSince time.Time
+	synced      bool
+
+	hooksMu        sync.Mutex
+	startedHooks   []func()
+	stoppedHooks   []func()
+	newLeaderHooks []func(identity string)
+
+	fencesMu sync.Mutex
+	fences   map[*fence]struct{}
+}
+
+// NewObserver creates an Observer for the Lease configured in config.LeaderElection.
+func NewObserver(client kubernetes.Interface, config ObserverConfig) (*Observer, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if config.LeaderElection == nil {
+		return nil, fmt.Errorf("LeaderElection is required")
+	}
+	if err := validateConfig(config.LeaderElection); err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+	leaseName, err := leaseNameFor(config.LeaderElection)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := resolveIdentity(config.Identity)
+	if err != nil {
+		return nil, err
+	}
+	config.Identity = identity
+	if config.PollInterval <= 0 {
+		if config.LeaderElection.RetryPeriod != nil && *config.LeaderElection.RetryPeriod > 0 {
+			config.PollInterval = *config.LeaderElection.RetryPeriod
+		} else {
+			config.PollInterval = DefaultPollInterval
+		}
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return &Observer{
+		client:    client,
+		config:    config,
+		namespace: config.LeaderElection.Namespace,
+		leaseName: leaseName,
+		logger: logging.NewLogger("zenlead-observer").
+			WithField("lease", config.LeaderElection.Namespace+"/"+leaseName),
+	}, nil
+}
+
+// leaseNameFor returns the name of the Lease used by le's mode.
+func leaseNameFor(le *LeaderElectionConfig) (string, error) {
+	switch le.Mode {
+	case BuiltIn:
+		return le.ElectionID, nil
+	case ZenLeadManaged:
+		return deriveElectionIDFromLeaseName(le.LeaseName), nil
+	default:
+		return "", fmt.Errorf("leader election mode %q has no Lease to observe", le.Mode)
+	}
+}
+
+// resolveIdentity returns identity, defaulting to the hostname (the pod name).
+func resolveIdentity(identity string) (string, error) {
+	if identity != "" {
+		return identity, nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "", fmt.Errorf("Identity is required when the hostname cannot be determined: %v", err)
+	}
+	return hostname, nil
+}
+
+// OnStartedLeading registers fn to be called when this instance becomes the leader.
+func (o *Observer) OnStartedLeading(fn func()) {
+	o.hooksMu.Lock()
+	defer o.hooksMu.Unlock()
+	o.startedHooks = append(o.startedHooks, fn)
+}
+
+// OnStoppedLeading registers fn to be called when this instance loses
+// leadership, including when Run returns while leading.
+func (o *Observer) OnStoppedLeading(fn func()) {
+	o.hooksMu.Lock()
+	defer o.hooksMu.Unlock()
+	o.stoppedHooks = append(o.stoppedHooks, fn)
+}
+
+// OnNewLeader registers fn to be called with the holder identity whenever the
+// Lease changes hands (including to this instance).
+func (o *Observer) OnNewLeader(fn func(identity string)) {
+	o.hooksMu.Lock()
+	defer o.hooksMu.Unlock()
+	o.newLeaderHooks = append(o.newLeaderHooks, fn)
+}
+
+// IsLeader reports whether this instance currently holds the Lease.
+func (o *Observer) IsLeader() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.isLeader
+}
+
+// CurrentHolder returns the identity holding the Lease, or "" if it is
+// unheld, expired or not yet observed.
+func (o *Observer) CurrentHolder() string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.holder
+}
+
+// LeaderSince returns when the current holder acquired the Lease (from its
+// acquireTime, or when the change was first observed), or the zero time if
+// there is no holder.
+func (o *Observer) LeaderSince() time.Time {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.leaderSince
+}
+
+// HasSynced reports whether the Lease has been read at least once since Run started.
+func (o *Observer) HasSynced() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.synced
+}
+
+// Identity returns the identity this Observer matches holders against.
+func (o *Observer) Identity() string {
+	return o.config.Identity
+}
+
+// Run reads the Lease every PollInterval until ctx is canceled. When it
+// returns, leadership can no longer be confirmed: IsLeader reports false and
+// the OnStoppedLeading hooks run if this instance was leading.
+func (o *Observer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(o.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := o.Sync(ctx); err != nil && ctx.Err() == nil {
+			o.logger.Error(err, "Failed to read leader election Lease",
+				logging.Operation("leader_observe"),
+				logging.ErrorCode("LEASE_GET_ERROR"))
+		}
+
+		select {
+		case <-ctx.Done():
+			o.reset()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync reads the Lease once and updates the leadership state. A missing
+// Lease means no holder. If the read fails, the last observed Lease is
+// re-evaluated so that leadership still lapses once it expires.
+func (o *Observer) Sync(ctx context.Context) error {
+	lease, err := o.client.CoordinationV1().Leases(o.namespace).Get(ctx, o.leaseName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		o.observe(nil)
+		return nil
+	case err != nil:
+		o.mu.RLock()
+		last := o.lease
+		o.mu.RUnlock()
+		o.observe(last)
+		return err
+	}
+	o.observe(lease)
+	return nil
+}
+
+// observe updates state from lease (nil if absent) and fires hooks for any transition.
+func (o *Observer) observe(lease *coordinationv1.Lease) {
+	o.eventMu.Lock()
+	defer o.eventMu.Unlock()
+
+	now := o.config.Now()
+	holder, acquired := o.leases.holder(lease, now)
+	isLeader := holder != "" && o.isOwnIdentity(holder)
+
+	o.mu.Lock()
+	prevHolder, wasLeader := o.holder, o.isLeader
+	o.lease = lease
+	o.holder = holder
+	o.isLeader = isLeader
+	o.synced = true
+	if holder != prevHolder {
+		o.leaderSince = time.Time{}
+		if holder != "" {
+			o.leaderSince = acquired
+			if o.leaderSince.IsZero() {
+				o.leaderSince = now
+			}
+		}
+	}
+	o.mu.Unlock()
+
+	o.releaseFences()
+	o.transition(prevHolder, holder, wasLeader, isLeader)
+}
+
+// reset forgets the observed Lease when Run stops.
+func (o *Observer) reset() {
+	o.eventMu.Lock()
+	defer o.eventMu.Unlock()
+
+	o.mu.Lock()
+	wasLeader := o.isLeader
+	o.lease = nil
+	o.holder = ""
+	o.isLeader = false
+	o.leaderSince = time.Time{}
+	o.synced = false
+	o.mu.Unlock()
+
+	o.releaseFences()
+	o.transition("", "", wasLeader, false)
+}
+
+// transition logs, records and dispatches hooks for a state change.
+// Caller must hold o.eventMu.
+func (o *Observer) transition(prevHolder, holder string, wasLeader, isLeader bool) {
+	o.hooksMu.Lock()
+	started := append([]func(){}, o.startedHooks...)
+	stopped := append([]func(){}, o.stoppedHooks...)
+	newLeader := append([]func(string){}, o.newLeaderHooks...)
+	o.hooksMu.Unlock()
+
+	if wasLeader && !isLeader {
+		o.logger.Info("Stopped leading",
+			logging.Operation("leader_observe"),
+			logging.String("identity", o.config.Identity))
+		o.recordTransition(TransitionStoppedLeading)
+		for _, fn := range stopped {
+			fn()
+		}
+	}
+	if holder != prevHolder && holder != "" {
+		o.logger.Info("New leader observed",
+			logging.Operation("leader_observe"),
+			logging.String("holder", holder),
+			logging.String("previous_holder", prevHolder))
+		o.recordTransition(TransitionNewLeader)
+		for _, fn := range newLeader {
+			fn(holder)
+		}
+	}
+	if isLeader && !wasLeader {
+		o.logger.Info("Started leading",
+			logging.Operation("leader_observe"),
+			logging.String("identity", o.config.Identity))
+		o.recordTransition(TransitionStartedLeading)
+		for _, fn := range started {
+			fn()
+		}
+	}
+	if o.config.Metrics != nil {
+		o.config.Metrics.SetLeader(o.leaseName, isLeader)
+	}
+}
+
+// recordTransition reports a transition to Metrics, if configured.
+func (o *Observer) recordTransition(transition string) {
+	if o.config.Metrics != nil {
+		o.config.Metrics.ObserveTransition(o.leaseName, transition)
+	}
+}
+
+// isOwnIdentity reports whether holder is this instance.
+func (o *Observer) isOwnIdentity(holder string) bool {
+	return holderMatches(holder, o.config.Identity)
+}
+
+// leaseTracker decides whether Leases have expired the way client-go's
+// LeaderElector does: by the local time at which this process last saw a
+// Lease's holder or renewTime change, plus leaseDurationSeconds. Comparing the
+// local clock with the remote renewTime instead would give wrong answers under
+// clock skew between nodes. A Lease seen for the first time therefore counts
+// as held for a full leaseDurationSeconds. The zero value is ready to use and
+// safe for concurrent use.
+type leaseTracker struct {
+	mu       sync.Mutex
+	observed map[string]observedLease // by namespace/name
+}
+
+// observedLease is the last record of a Lease and when it was first seen locally.
+type observedLease struct {
+	holder     string
+	renewTime  time.Time
+	observedAt time.Time
+}
+
+// holder returns the holder of lease and when it was acquired, or "" if the
+// Lease is absent or unheld, or if its record has not changed for
+// leaseDurationSeconds of local time up to now.
+func (t *leaseTracker) holder(lease *coordinationv1.Lease, now time.Time) (string, time.Time) {
+	if lease == nil || lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		return "", time.Time{}
+	}
+	holder := *lease.Spec.HolderIdentity
+
+	var acquired, renewed time.Time
+	if lease.Spec.AcquireTime != nil {
+		acquired = lease.Spec.AcquireTime.Time
+	}
+	renewed = acquired
+	if lease.Spec.RenewTime != nil {
+		renewed = lease.Spec.RenewTime.Time
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.observed == nil {
+		t.observed = make(map[string]observedLease)
+	}
+	key := lease.Namespace + "/" + lease.Name
+	record, ok := t.observed[key]
+	if !ok || record.holder != holder || !record.renewTime.Equal(renewed) {
+		record = observedLease{holder: holder, renewTime: renewed, observedAt: now}
+		t.observed[key] = record
+	}
+
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		if now.After(record.observedAt.Add(duration)) {
+			return "", time.Time{}
+		}
+	}
+	return holder, acquired
+}
+
+// retain forgets Leases not in keep (namespace/name), e.g. members that left.
+func (t *leaseTracker) retain(keep map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key := range t.observed {
+		if !keep[key] {
+			delete(t.observed, key)
+		}
+	}
+}
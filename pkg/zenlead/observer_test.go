@@ -0,0 +1,335 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var observerNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func builtInConfig() *LeaderElectionConfig {
+	return &LeaderElectionConfig{
+		Mode:       BuiltIn,
+		ElectionID: "test-controller-leader-election",
+		Namespace:  "test-namespace",
+	}
+}
+
+// newLease returns a Lease held by holder, acquired and renewed at renewed.
+func newLease(name, holder string, renewed time.Time) *coordinationv1.Lease {
+	duration := int32(15)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-namespace"},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			AcquireTime:          &metav1.MicroTime{Time: renewed},
+			RenewTime:            &metav1.MicroTime{Time: renewed},
+		},
+	}
+}
+
+// updateLease replaces the Lease in the fake clientset.
+func updateLease(t *testing.T, client *fake.Clientset, lease *coordinationv1.Lease) {
+	t.Helper()
+	if _, err := client.CoordinationV1().Leases(lease.Namespace).Update(context.Background(), lease, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update lease: %v", err)
+	}
+}
+
+// recordingMetrics records transitions in order.
+type recordingMetrics struct {
+	mu          sync.Mutex
+	transitions []string
+	isLeader    bool
+}
+
+func (m *recordingMetrics) ObserveTransition(_, transition string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transitions = append(m.transitions, transition)
+}
+
+func (m *recordingMetrics) SetLeader(_ string, isLeader bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.isLeader = isLeader
+}
+
+func TestObserver_Transitions(t *testing.T) {
+	now := observerNow
+	lease := newLease("test-controller-leader-election", "pod-b_1234", now)
+	client := fake.NewClientset(lease)
+	metrics := &recordingMetrics{}
+	o, err := NewObserver(client, ObserverConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Metrics:        metrics,
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+	var events []string
+	o.OnStartedLeading(func() { events = append(events, "started") })
+	o.OnStoppedLeading(func() { events = append(events, "stopped") })
+	o.OnNewLeader(func(identity string) { events = append(events, "new:"+identity) })
+	ctx := context.Background()
+
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if o.IsLeader() || o.CurrentHolder() != "pod-b_1234" || !o.LeaderSince().Equal(now) {
+		t.Errorf("after pod-b: IsLeader=%v holder=%q since=%v", o.IsLeader(), o.CurrentHolder(), o.LeaderSince())
+	}
+
+	// controller-runtime identities are "<hostname>_<uuid>"
+	now = now.Add(20 * time.Second)
+	updateLease(t, client, newLease("test-controller-leader-election", "pod-a_5678", now))
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if !o.IsLeader() || !o.LeaderSince().Equal(now) {
+		t.Errorf("after pod-a: IsLeader=%v since=%v, want leader since %v", o.IsLeader(), o.LeaderSince(), now)
+	}
+
+	// Renewals by the same holder are not transitions
+	now = now.Add(5 * time.Second)
+	renewed := newLease("test-controller-leader-election", "pod-a_5678", now.Add(-5*time.Second))
+	renewed.Spec.RenewTime = &metav1.MicroTime{Time: now}
+	updateLease(t, client, renewed)
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+
+	updateLease(t, client, newLease("test-controller-leader-election", "pod-c_9", now))
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+
+	want := []string{"new:pod-b_1234", "new:pod-a_5678", "started", "stopped", "new:pod-c_9"}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+	wantTransitions := []string{
+		TransitionNewLeader, TransitionNewLeader, TransitionStartedLeading,
+		TransitionStoppedLeading, TransitionNewLeader,
+	}
+	if !reflect.DeepEqual(metrics.transitions, wantTransitions) || metrics.isLeader {
+		t.Errorf("metrics = %v (leader=%v), want %v", metrics.transitions, metrics.isLeader, wantTransitions)
+	}
+}
+
+func TestObserver_ExpiredLease(t *testing.T) {
+	now := observerNow
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a", now))
+	o, err := NewObserver(client, ObserverConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+	var events []string
+	o.OnStartedLeading(func() { events = append(events, "started") })
+	o.OnStoppedLeading(func() { events = append(events, "stopped") })
+	o.OnNewLeader(func(identity string) { events = append(events, "new:"+identity) })
+	ctx := context.Background()
+
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if !o.IsLeader() {
+		t.Fatal("IsLeader() = false, want true")
+	}
+
+	// The leader stopped renewing: once leaseDurationSeconds passes, nobody leads
+	now = now.Add(16 * time.Second)
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if o.IsLeader() || o.CurrentHolder() != "" || !o.LeaderSince().IsZero() {
+		t.Errorf("after expiry: IsLeader=%v holder=%q since=%v", o.IsLeader(), o.CurrentHolder(), o.LeaderSince())
+	}
+	if want := []string{"new:pod-a", "started", "stopped"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+}
+
+func TestObserver_ClockSkew(t *testing.T) {
+	now := observerNow
+	// The leader's clock is an hour behind ours
+	skewed := now.Add(-time.Hour)
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-b", skewed))
+	o, err := NewObserver(client, ObserverConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := o.Sync(ctx); err != nil {
+			t.Fatalf("Sync() unexpected error: %v", err)
+		}
+		if o.CurrentHolder() != "pod-b" {
+			t.Fatalf("renewal %d: holder = %q, want pod-b while it keeps renewing", i, o.CurrentHolder())
+		}
+		now = now.Add(10 * time.Second)
+		skewed = skewed.Add(10 * time.Second)
+		renewed := newLease("test-controller-leader-election", "pod-b", observerNow.Add(-time.Hour))
+		renewed.Spec.RenewTime = &metav1.MicroTime{Time: skewed}
+		updateLease(t, client, renewed)
+	}
+
+	// It stops renewing: expiry is measured from when we last saw a renewal
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	now = now.Add(16 * time.Second)
+	if err := o.Sync(ctx); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if o.CurrentHolder() != "" {
+		t.Errorf("after the leader stopped renewing: holder = %q, want none", o.CurrentHolder())
+	}
+}
+
+func TestObserver_MissingLease(t *testing.T) {
+	now := observerNow
+	o, err := NewObserver(fake.NewClientset(), ObserverConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+	var events []string
+	o.OnStartedLeading(func() { events = append(events, "started") })
+	o.OnStoppedLeading(func() { events = append(events, "stopped") })
+	o.OnNewLeader(func(identity string) { events = append(events, "new:"+identity) })
+
+	if err := o.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if !o.HasSynced() || o.IsLeader() || o.CurrentHolder() != "" || len(events) != 0 {
+		t.Errorf("missing lease: synced=%v leader=%v holder=%q events=%v", o.HasSynced(), o.IsLeader(), o.CurrentHolder(), events)
+	}
+}
+
+func TestObserver_ZenLeadManaged(t *testing.T) {
+	now := observerNow
+	le := &LeaderElectionConfig{Mode: ZenLeadManaged, LeaseName: "test-leader-group", Namespace: "test-namespace"}
+	client := fake.NewClientset(newLease("test-leader-group-lease", "pod-a", now))
+	o, err := NewObserver(client, ObserverConfig{
+		LeaderElection: le,
+		Identity:       "pod-a",
+		Now:            func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+
+	if err := o.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+	if !o.IsLeader() {
+		t.Error("IsLeader() = false, want true for the zen-lead managed Lease")
+	}
+}
+
+func TestObserver_Run(t *testing.T) {
+	now := time.Now()
+	client := fake.NewClientset(newLease("test-controller-leader-election", "pod-a", now))
+	o, err := NewObserver(client, ObserverConfig{
+		LeaderElection: builtInConfig(),
+		Identity:       "pod-a",
+		PollInterval:   10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewObserver() unexpected error: %v", err)
+	}
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	o.OnStartedLeading(func() { close(started) })
+	o.OnStoppedLeading(func() { close(stopped) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- o.Run(ctx) }()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnStartedLeading was not called")
+	}
+
+	// Leadership can no longer be confirmed once the observer stops
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() unexpected error: %v", err)
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Error("OnStoppedLeading was not called when Run returned")
+	}
+	if o.IsLeader() || o.HasSynced() {
+		t.Errorf("after Run: IsLeader=%v HasSynced=%v, want false", o.IsLeader(), o.HasSynced())
+	}
+}
+
+func TestNewObserver_Errors(t *testing.T) {
+	client := fake.NewClientset()
+	tests := []struct {
+		name   string
+		client *fake.Clientset
+		config ObserverConfig
+	}{
+		{name: "nil client", config: ObserverConfig{LeaderElection: builtInConfig()}},
+		{name: "missing config", client: client},
+		{name: "invalid config", client: client, config: ObserverConfig{LeaderElection: &LeaderElectionConfig{Mode: BuiltIn}}},
+		{name: "disabled", client: client, config: ObserverConfig{LeaderElection: &LeaderElectionConfig{Mode: Disabled}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.client == nil {
+				_, err = NewObserver(nil, tt.config)
+			} else {
+				_, err = NewObserver(tt.client, tt.config)
+			}
+			if err == nil {
+				t.Error("NewObserver() expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kube-zen/zen-sdk/pkg/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// Labels zen-lead sets on the Lease it provisions for a LeaderGroup.
+const (
+	// LabelLeaderGroup is set to the owning LeaderGroup's name.
+	LabelLeaderGroup = "leadership.kube-zen.io/leader-group"
+	// LabelManagedBy is set to ManagedByZenLead.
+	LabelManagedBy = "app.kubernetes.io/managed-by"
+	// ManagedByZenLead is the LabelManagedBy value on zen-lead Leases.
+	ManagedByZenLead = "zen-lead"
+)
+
+// LeaderGroupTypeController is the LeaderGroup spec.type for controller HA (Profile C).
+const LeaderGroupTypeController = "controller"
+
+var (
+	// LeaderGroupGVR is the zen-lead LeaderGroup resource.
+	LeaderGroupGVR = schema.GroupVersionResource{Group: "leadership.kube-zen.io", Version: "v1alpha1", Resource: "leadergroups"}
+
+	leaseGVR = schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}
+)
+
+// ErrZenLeadNotReady is wrapped when zen-lead has not provisioned the
+// LeaderGroup and Lease a ZenLeadManaged component expects.
+var ErrZenLeadNotReady = errors.New("zen-lead managed leadership not ready")
+
+// ManagedFallbackPolicy decides what PreflightZenLeadManaged does when zen-lead
+// has not provisioned the LeaderGroup or its Lease.
+type ManagedFallbackPolicy string
+
+const (
+	// ManagedFallbackFail fails startup. This is the default.
+	ManagedFallbackFail ManagedFallbackPolicy = "fail"
+
+	// ManagedFallbackBuiltIn falls back to BuiltIn mode with a component-owned Lease.
+	ManagedFallbackBuiltIn ManagedFallbackPolicy = "builtin"
+)
+
+// PreflightZenLeadManaged verifies, in ZenLeadManaged mode, that the
+// LeaderGroup named by le.LeaseName exists with spec.type "controller" and
+// that zen-lead has labeled its Lease. Otherwise, depending on
+// le.ManagedFallback, it fails with ErrZenLeadNotReady or returns a BuiltIn
+// copy of le (ElectionID, or the derived Lease name if unset). Configs in
+// other modes are returned unchanged. Other API errors (e.g. missing RBAC) are
+// always returned.
+//
+// Call it before PrepareManagerOptions so a missing zen-lead does not silently
+// turn into a component-owned Lease. It needs RBAC get on leadergroups and leases.
+func PreflightZenLeadManaged(ctx context.Context, client dynamic.Interface, le *LeaderElectionConfig) (*LeaderElectionConfig, error) {
+	if le.Mode != ZenLeadManaged {
+		return le, nil
+	}
+	if client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if err := validateConfig(le); err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+
+	err := checkZenLeadManaged(ctx, client, le)
+	if err == nil {
+		return le, nil
+	}
+	if !errors.Is(err, ErrZenLeadNotReady) || le.ManagedFallback != ManagedFallbackBuiltIn {
+		return nil, err
+	}
+
+	fallback := *le
+	fallback.Mode = BuiltIn
+	if fallback.ElectionID == "" {
+		fallback.ElectionID = deriveElectionIDFromLeaseName(le.LeaseName)
+	}
+	logging.NewLogger("zenlead").Warn("zen-lead managed leadership not ready, falling back to built-in leader election",
+		logging.Operation("zenlead_preflight"),
+		logging.Namespace(le.Namespace),
+		logging.String("leader_group", le.LeaseName),
+		logging.String("election_id", fallback.ElectionID),
+		logging.String("reason", err.Error()))
+	return &fallback, nil
+}
+
+// checkZenLeadManaged checks the LeaderGroup and its Lease, wrapping
+// ErrZenLeadNotReady if either is missing or not what zen-lead provisions.
+func checkZenLeadManaged(ctx context.Context, client dynamic.Interface, le *LeaderElectionConfig) error {
+	group, err := client.Resource(LeaderGroupGVR).Namespace(le.Namespace).Get(ctx, le.LeaseName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: LeaderGroup %s/%s not found (is zen-lead installed with leadergroups enabled?)",
+			ErrZenLeadNotReady, le.Namespace, le.LeaseName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get LeaderGroup %s/%s: %w", le.Namespace, le.LeaseName, err)
+	}
+	groupType, _, err := unstructured.NestedString(group.Object, "spec", "type")
+	if err != nil {
+		return fmt.Errorf("%w: LeaderGroup %s/%s has an invalid spec.type: %v", ErrZenLeadNotReady, le.Namespace, le.LeaseName, err)
+	}
+	if groupType != "" && groupType != LeaderGroupTypeController {
+		return fmt.Errorf("%w: LeaderGroup %s/%s has spec.type %q, want %q",
+			ErrZenLeadNotReady, le.Namespace, le.LeaseName, groupType, LeaderGroupTypeController)
+	}
+
+	leaseName := deriveElectionIDFromLeaseName(le.LeaseName)
+	lease, err := client.Resource(leaseGVR).Namespace(le.Namespace).Get(ctx, leaseName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: Lease %s/%s for LeaderGroup %s not found", ErrZenLeadNotReady, le.Namespace, leaseName, le.LeaseName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get Lease %s/%s: %w", le.Namespace, leaseName, err)
+	}
+	labels := lease.GetLabels()
+	for _, label := range [][2]string{{LabelLeaderGroup, le.LeaseName}, {LabelManagedBy, ManagedByZenLead}} {
+		if got := labels[label[0]]; got != label[1] {
+			return fmt.Errorf("%w: Lease %s/%s has label %s=%q, want %q",
+				ErrZenLeadNotReady, le.Namespace, leaseName, label[0], got, label[1])
+		}
+	}
+	return nil
+}
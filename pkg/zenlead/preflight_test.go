@@ -0,0 +1,175 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newLeaderGroup(name, groupType string) *unstructured.Unstructured {
+	group := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "leadership.kube-zen.io/v1alpha1",
+		"kind":       "LeaderGroup",
+		"metadata":   map[string]interface{}{"name": name, "namespace": testNamespace},
+	}}
+	if groupType != "" {
+		_ = unstructured.SetNestedField(group.Object, groupType, "spec", "type")
+	}
+	return group
+}
+
+func newManagedLease(name string, labels map[string]string) *unstructured.Unstructured {
+	lease := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "coordination.k8s.io/v1",
+		"kind":       "Lease",
+		"metadata":   map[string]interface{}{"name": name, "namespace": testNamespace},
+	}}
+	lease.SetLabels(labels)
+	return lease
+}
+
+func zenLeadLabels(group string) map[string]string {
+	return map[string]string{LabelLeaderGroup: group, LabelManagedBy: ManagedByZenLead}
+}
+
+func newDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+}
+
+func managedConfig(fallback ManagedFallbackPolicy) *LeaderElectionConfig {
+	return &LeaderElectionConfig{
+		Mode:            ZenLeadManaged,
+		LeaseName:       "my-controller",
+		Namespace:       testNamespace,
+		ManagedFallback: fallback,
+	}
+}
+
+func TestPreflightZenLeadManaged(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		wantErr string
+	}{
+		{
+			name:    "provisioned",
+			objects: []runtime.Object{newLeaderGroup("my-controller", "controller"), newManagedLease("my-controller-lease", zenLeadLabels("my-controller"))},
+		},
+		{
+			name:    "spec.type unset",
+			objects: []runtime.Object{newLeaderGroup("my-controller", ""), newManagedLease("my-controller-lease", zenLeadLabels("my-controller"))},
+		},
+		{
+			name:    "LeaderGroup missing",
+			objects: []runtime.Object{newManagedLease("my-controller-lease", zenLeadLabels("my-controller"))},
+			wantErr: "LeaderGroup test-namespace/my-controller not found",
+		},
+		{
+			name:    "LeaderGroup for network routing",
+			objects: []runtime.Object{newLeaderGroup("my-controller", "network"), newManagedLease("my-controller-lease", zenLeadLabels("my-controller"))},
+			wantErr: `spec.type "network"`,
+		},
+		{
+			name:    "Lease missing",
+			objects: []runtime.Object{newLeaderGroup("my-controller", "controller")},
+			wantErr: "Lease test-namespace/my-controller-lease for LeaderGroup my-controller not found",
+		},
+		{
+			name:    "Lease created by the component",
+			objects: []runtime.Object{newLeaderGroup("my-controller", "controller"), newManagedLease("my-controller-lease", nil)},
+			wantErr: LabelLeaderGroup + `=""`,
+		},
+		{
+			name: "Lease managed by something else",
+			objects: []runtime.Object{
+				newLeaderGroup("my-controller", "controller"),
+				newManagedLease("my-controller-lease", map[string]string{LabelLeaderGroup: "my-controller", LabelManagedBy: "helm"}),
+			},
+			wantErr: LabelManagedBy + `="helm"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			le := managedConfig("")
+			got, err := PreflightZenLeadManaged(context.Background(), newDynamicClient(tt.objects...), le)
+			if tt.wantErr == "" {
+				if err != nil || got != le {
+					t.Errorf("PreflightZenLeadManaged() = %+v, %v, want the config unchanged", got, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrZenLeadNotReady) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PreflightZenLeadManaged() error = %v, want ErrZenLeadNotReady containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreflightZenLeadManaged_FallbackBuiltIn(t *testing.T) {
+	le := managedConfig(ManagedFallbackBuiltIn)
+	got, err := PreflightZenLeadManaged(context.Background(), newDynamicClient(), le)
+	if err != nil {
+		t.Fatalf("PreflightZenLeadManaged() unexpected error: %v", err)
+	}
+	if got.Mode != BuiltIn || got.ElectionID != "my-controller-lease" || got.Namespace != testNamespace {
+		t.Errorf("PreflightZenLeadManaged() = %+v, want BuiltIn with the derived Lease name", got)
+	}
+	if le.Mode != ZenLeadManaged {
+		t.Error("PreflightZenLeadManaged() modified the input config")
+	}
+
+	le.ElectionID = "my-controller-leader-election"
+	got, _ = PreflightZenLeadManaged(context.Background(), newDynamicClient(), le)
+	if got.ElectionID != "my-controller-leader-election" {
+		t.Errorf("fallback ElectionID = %q, want the configured ElectionID", got.ElectionID)
+	}
+}
+
+func TestPreflightZenLeadManaged_APIErrorsDoNotFallBack(t *testing.T) {
+	client := newDynamicClient()
+	client.PrependReactor("get", "leadergroups", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(LeaderGroupGVR.GroupResource(), "my-controller", errors.New("no RBAC"))
+	})
+
+	_, err := PreflightZenLeadManaged(context.Background(), client, managedConfig(ManagedFallbackBuiltIn))
+	if err == nil || errors.Is(err, ErrZenLeadNotReady) || !apierrors.IsForbidden(err) {
+		t.Errorf("PreflightZenLeadManaged() error = %v, want the Forbidden error", err)
+	}
+}
+
+func TestPreflightZenLeadManaged_OtherModes(t *testing.T) {
+	le := &LeaderElectionConfig{Mode: BuiltIn, ElectionID: "id", Namespace: testNamespace}
+	got, err := PreflightZenLeadManaged(context.Background(), nil, le)
+	if err != nil || got != le {
+		t.Errorf("PreflightZenLeadManaged() = %+v, %v, want BuiltIn config unchanged", got, err)
+	}
+
+	_, err = PreflightZenLeadManaged(context.Background(), newDynamicClient(), managedConfig("maybe"))
+	if err == nil || !strings.Contains(err.Error(), "invalid ManagedFallback") {
+		t.Errorf("PreflightZenLeadManaged() error = %v, want invalid ManagedFallback", err)
+	}
+}
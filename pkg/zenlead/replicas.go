@@ -0,0 +1,191 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ErrReplicasUnknown is returned when the replica count of a pod's workload
+// cannot be determined (e.g., it is owned by a DaemonSet or a custom controller).
+var ErrReplicasUnknown = errors.New("cannot determine replica count")
+
+// ReplicaInfo describes how many replicas of a pod's workload may run.
+type ReplicaInfo struct {
+	// Kind and Name identify the workload: Deployment, StatefulSet,
+	// ReplicaSet (without a Deployment) or Pod (no controller).
+	Kind string
+	Name string
+
+	// Replicas is the workload's spec.replicas (1 for a bare pod).
+	Replicas int
+
+	// HPAMaxReplicas is spec.maxReplicas of the HorizontalPodAutoscaler
+	// targeting the workload, or 0 if there is none.
+	HPAMaxReplicas int
+}
+
+// MaxReplicas returns the largest number of replicas the workload may be scaled to.
+func (r *ReplicaInfo) MaxReplicas() int {
+	return max(r.Replicas, r.HPAMaxReplicas)
+}
+
+// DetectReplicas finds the current pod from POD_NAME and POD_NAMESPACE
+// (falling back to the service account namespace) and returns its workload's
+// replica counts. See DetectPodReplicas.
+func DetectReplicas(ctx context.Context, client kubernetes.Interface) (*ReplicaInfo, error) {
+	podName := os.Getenv("POD_NAME")
+	if podName == "" {
+		return nil, fmt.Errorf("POD_NAME environment variable must be set (Downward API) to detect replicas")
+	}
+	namespace, err := PodNamespace()
+	if err != nil {
+		return nil, err
+	}
+	return DetectPodReplicas(ctx, client, namespace, podName)
+}
+
+// DetectPodReplicas follows the pod's controller ownerReferences to its
+// Deployment (through the ReplicaSet), StatefulSet or standalone ReplicaSet
+// and reads spec.replicas, plus spec.maxReplicas of any HorizontalPodAutoscaler
+// targeting that workload. A pod without a controller counts as one replica.
+//
+// Requires get on pods, replicasets, deployments and statefulsets, and list on
+// horizontalpodautoscalers, in the pod's namespace.
+func DetectPodReplicas(ctx context.Context, client kubernetes.Interface, namespace, podName string) (*ReplicaInfo, error) {
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s/%s: %w", namespace, podName, err)
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return &ReplicaInfo{Kind: "Pod", Name: podName, Replicas: 1}, nil
+	}
+
+	info, err := workloadReplicas(ctx, client, namespace, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	hpaMax, err := hpaMaxReplicas(ctx, client, namespace, info.Kind, info.Name)
+	if err != nil {
+		return nil, err
+	}
+	info.HPAMaxReplicas = hpaMax
+	return info, nil
+}
+
+// workloadReplicas resolves owner to the top-level workload and its spec.replicas.
+func workloadReplicas(ctx context.Context, client kubernetes.Interface, namespace string, owner *metav1.OwnerReference) (*ReplicaInfo, error) {
+	switch {
+	case isAppsKind(owner, "ReplicaSet"):
+		rs, err := client.AppsV1().ReplicaSets(namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicaset %s/%s: %w", namespace, owner.Name, err)
+		}
+		if deployOwner := metav1.GetControllerOf(rs); deployOwner != nil {
+			if !isAppsKind(deployOwner, "Deployment") {
+				return nil, fmt.Errorf("%w: replicaset %s is controlled by %s %s",
+					ErrReplicasUnknown, rs.Name, deployOwner.Kind, deployOwner.Name)
+			}
+			deploy, err := client.AppsV1().Deployments(namespace).Get(ctx, deployOwner.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, deployOwner.Name, err)
+			}
+			return &ReplicaInfo{Kind: "Deployment", Name: deploy.Name, Replicas: specReplicas(deploy.Spec.Replicas)}, nil
+		}
+		return &ReplicaInfo{Kind: "ReplicaSet", Name: rs.Name, Replicas: specReplicas(rs.Spec.Replicas)}, nil
+
+	case isAppsKind(owner, "StatefulSet"):
+		sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset %s/%s: %w", namespace, owner.Name, err)
+		}
+		return &ReplicaInfo{Kind: "StatefulSet", Name: sts.Name, Replicas: specReplicas(sts.Spec.Replicas)}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: pod is controlled by %s %s", ErrReplicasUnknown, owner.Kind, owner.Name)
+	}
+}
+
+// hpaMaxReplicas returns spec.maxReplicas of the HPA scaling kind/name, or 0.
+func hpaMaxReplicas(ctx context.Context, client kubernetes.Interface, namespace, kind, name string) (int, error) {
+	hpas, err := client.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list horizontalpodautoscalers in %s: %w", namespace, err)
+	}
+
+	maxReplicas := 0
+	for i := range hpas.Items {
+		ref := hpas.Items[i].Spec.ScaleTargetRef
+		if ref.Kind != kind || ref.Name != name || !strings.HasPrefix(ref.APIVersion, appsv1.GroupName+"/") {
+			continue
+		}
+		maxReplicas = max(maxReplicas, int(hpas.Items[i].Spec.MaxReplicas))
+	}
+	return maxReplicas, nil
+}
+
+// isAppsKind reports whether ref points to an apps/* object of kind.
+func isAppsKind(ref *metav1.OwnerReference, kind string) bool {
+	return ref.Kind == kind && strings.HasPrefix(ref.APIVersion, appsv1.GroupName+"/")
+}
+
+// specReplicas returns replicas, defaulting to 1 like the API server.
+func specReplicas(replicas *int32) int {
+	if replicas == nil {
+		return 1
+	}
+	return int(*replicas)
+}
+
+// EnforceSafeHAForPod is EnforceSafeHA with the replica count detected from
+// the cluster (see DetectReplicas) instead of passed by the caller. It uses
+// the larger of spec.replicas and the HPA's maxReplicas, so a Deployment that
+// is scaled to 1 but may autoscale to 3 is rejected.
+//
+// When leader election is enabled any replica count is safe and nothing is
+// read. When it is disabled and the count cannot be determined, it fails:
+// pass the count to EnforceSafeHA explicitly in that case.
+//
+// Note that a Deployment with the RollingUpdate strategy briefly runs an extra
+// pod during rollouts; use the Recreate strategy when running without leader
+// election.
+func EnforceSafeHAForPod(ctx context.Context, client kubernetes.Interface, leaderElectionEnabled bool) error {
+	if leaderElectionEnabled {
+		return nil
+	}
+
+	info, err := DetectReplicas(ctx, client)
+	if err != nil {
+		return fmt.Errorf("leader election is disabled and the replica count could not be verified: %w", err)
+	}
+	if err := EnforceSafeHA(info.MaxReplicas(), false); err != nil {
+		return fmt.Errorf("%s %s (replicas=%d, hpaMaxReplicas=%d): %w",
+			info.Kind, info.Name, info.Replicas, info.HPAMaxReplicas, err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "test-namespace"
+
+func controllerRef(apiVersion, kind, name string) []metav1.OwnerReference {
+	isController := true
+	return []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, Controller: &isController}}
+}
+
+func newOwnedPod(owners []metav1.OwnerReference) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "controller-pod", Namespace: testNamespace, OwnerReferences: owners,
+	}}
+}
+
+func newHPA(kind, name string, maxReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-hpa", Namespace: testNamespace},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: kind, Name: name},
+			MaxReplicas:    maxReplicas,
+		},
+	}
+}
+
+// deploymentObjects returns a Deployment with the given replicas, its
+// ReplicaSet and a pod owned by it.
+func deploymentObjects(replicas *int32) []runtime.Object {
+	return []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "controller", Namespace: testNamespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "controller-7d9f", Namespace: testNamespace,
+			OwnerReferences: controllerRef("apps/v1", "Deployment", "controller"),
+		}},
+		newOwnedPod(controllerRef("apps/v1", "ReplicaSet", "controller-7d9f")),
+	}
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestDetectPodReplicas(t *testing.T) {
+	standaloneRS := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: testNamespace},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: int32Ptr(2)},
+	}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    ReplicaInfo
+	}{
+		{
+			name:    "deployment",
+			objects: deploymentObjects(int32Ptr(1)),
+			want:    ReplicaInfo{Kind: "Deployment", Name: "controller", Replicas: 1},
+		},
+		{
+			name:    "deployment defaults to one replica",
+			objects: deploymentObjects(nil),
+			want:    ReplicaInfo{Kind: "Deployment", Name: "controller", Replicas: 1},
+		},
+		{
+			name:    "deployment with HPA",
+			objects: append(deploymentObjects(int32Ptr(1)), newHPA("Deployment", "controller", 5), newHPA("Deployment", "other", 10)),
+			want:    ReplicaInfo{Kind: "Deployment", Name: "controller", Replicas: 1, HPAMaxReplicas: 5},
+		},
+		{
+			name: "statefulset",
+			objects: []runtime.Object{
+				&appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "controller", Namespace: testNamespace},
+					Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(3)},
+				},
+				newOwnedPod(controllerRef("apps/v1", "StatefulSet", "controller")),
+			},
+			want: ReplicaInfo{Kind: "StatefulSet", Name: "controller", Replicas: 3},
+		},
+		{
+			name:    "standalone replicaset",
+			objects: []runtime.Object{standaloneRS, newOwnedPod(controllerRef("apps/v1", "ReplicaSet", "standalone"))},
+			want:    ReplicaInfo{Kind: "ReplicaSet", Name: "standalone", Replicas: 2},
+		},
+		{
+			name:    "bare pod",
+			objects: []runtime.Object{newOwnedPod(nil)},
+			want:    ReplicaInfo{Kind: "Pod", Name: "controller-pod", Replicas: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewClientset(tt.objects...)
+			got, err := DetectPodReplicas(context.Background(), client, testNamespace, "controller-pod")
+			if err != nil {
+				t.Fatalf("DetectPodReplicas() unexpected error: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("DetectPodReplicas() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectPodReplicas_Unknown(t *testing.T) {
+	client := fake.NewClientset(newOwnedPod(controllerRef("apps/v1", "DaemonSet", "agent")))
+	_, err := DetectPodReplicas(context.Background(), client, testNamespace, "controller-pod")
+	if !errors.Is(err, ErrReplicasUnknown) {
+		t.Errorf("DetectPodReplicas() error = %v, want ErrReplicasUnknown", err)
+	}
+}
+
+func TestEnforceSafeHAForPod(t *testing.T) {
+	tests := []struct {
+		name                  string
+		objects               []runtime.Object
+		leaderElectionEnabled bool
+		wantErr               bool
+	}{
+		{
+			name:    "Safe: one replica, leader election disabled",
+			objects: deploymentObjects(int32Ptr(1)),
+		},
+		{
+			name:    "Unsafe: two replicas, leader election disabled",
+			objects: deploymentObjects(int32Ptr(2)),
+			wantErr: true,
+		},
+		{
+			name:    "Unsafe: one replica but HPA may scale to three",
+			objects: append(deploymentObjects(int32Ptr(1)), newHPA("Deployment", "controller", 3)),
+			wantErr: true,
+		},
+		{
+			name:    "Unsafe: replica count unknown",
+			objects: []runtime.Object{newOwnedPod(controllerRef("apps/v1", "DaemonSet", "agent"))},
+			wantErr: true,
+		},
+		{
+			name:                  "Safe: leader election enabled",
+			objects:               deploymentObjects(int32Ptr(3)),
+			leaderElectionEnabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("POD_NAME", "controller-pod")
+			t.Setenv("POD_NAMESPACE", testNamespace)
+
+			client := fake.NewClientset(tt.objects...)
+			err := EnforceSafeHAForPod(context.Background(), client, tt.leaderElectionEnabled)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EnforceSafeHAForPod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDetectReplicas_RequiresPodName(t *testing.T) {
+	t.Setenv("POD_NAME", "")
+	t.Setenv("POD_NAMESPACE", testNamespace)
+
+	if _, err := DetectReplicas(context.Background(), fake.NewClientset()); err == nil {
+		t.Error("DetectReplicas() expected error without POD_NAME")
+	}
+}
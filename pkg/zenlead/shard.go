@@ -0,0 +1,572 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kube-zen/zen-sdk/pkg/logging"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Labels on the Leases created by a ShardedElector.
+const (
+	// LabelShardElection is set to the base Lease name on shard and member Leases.
+	LabelShardElection = "leadership.kube-zen.io/shard-election"
+	// LabelShardRole is "shard" on shard Leases and "member" on member Leases.
+	LabelShardRole = "leadership.kube-zen.io/shard-role"
+)
+
+// ShardConfig holds ShardedElector configuration.
+type ShardConfig struct {
+	// LeaderElection provides the base Lease name (ElectionID, or the derived
+	// name in ZenLeadManaged mode), namespace and timings. Required.
+	LeaderElection *LeaderElectionConfig
+
+	// Shards is the number of shards. Required. Every replica must use the
+	// same value; changing it reassigns keys (see ShardFor).
+	Shards int
+
+	// Identity is this replica's identity prefix (default: the hostname).
+	// A random suffix is appended, as controller-runtime does, so a
+	// restarted pod never mistakes its previous leases for its own.
+	Identity string
+
+	// KeyFunc maps an object to its shard key (default: "<namespace>/<name>").
+	KeyFunc func(obj client.Object) string
+
+	// Metrics optionally receives per-shard leadership transitions, labeled
+	// by shard Lease name.
+	Metrics Metrics
+}
+
+// ShardedElector spreads N shards of work across replicas. Each shard has its
+// own Lease, "<election-id>-shard-<i>", and keys are assigned to shards by
+// consistent hashing, so each replica processes only the keys of the shards
+// whose Lease it holds.
+//
+// Replicas announce themselves with a member Lease each. Every RetryPeriod
+// each replica lists the live members and computes the same balanced
+// assignment of shards to members (at most ceil(N/members) per member,
+// preferring a stable rendezvous-hash owner so that joins and leaves move few
+// shards). A replica campaigns only for its assigned shards and releases
+// shards assigned elsewhere, so shards are rebalanced within a few
+// RetryPeriods of a replica joining or leaving. A shard may briefly have no
+// owner during a handoff, but never two.
+type ShardedElector struct {
+	client    kubernetes.Interface
+	config    ShardConfig
+	namespace string
+	baseName  string
+	identity  string
+	timings   Timings
+	logger    *logging.Logger
+	members   leaseTracker
+
+	mu       sync.RWMutex
+	owned    map[int]bool
+	running  map[int]*shardRun
+	assigned []int
+
+	hooksMu       sync.Mutex
+	acquiredHooks []func(shard int)
+	releasedHooks []func(shard int)
+}
+
+// shardRun is a running LeaderElector for one shard.
+type shardRun struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewShardedElector creates a ShardedElector.
+func NewShardedElector(clientset kubernetes.Interface, config ShardConfig) (*ShardedElector, error) {
+	if clientset == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if config.LeaderElection == nil {
+		return nil, fmt.Errorf("LeaderElection is required")
+	}
+	if err := validateConfig(config.LeaderElection); err != nil {
+		return nil, fmt.Errorf("invalid leader election config: %w", err)
+	}
+	if config.Shards <= 0 {
+		return nil, fmt.Errorf("Shards must be greater than zero, got %d", config.Shards)
+	}
+	baseName, err := leaseNameFor(config.LeaderElection)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := resolveIdentity(config.Identity)
+	if err != nil {
+		return nil, err
+	}
+	if config.KeyFunc == nil {
+		config.KeyFunc = func(obj client.Object) string {
+			return obj.GetNamespace() + "/" + obj.GetName()
+		}
+	}
+
+	le := config.LeaderElection
+	timings, err := le.EffectiveTimings()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShardedElector{
+		client:    clientset,
+		config:    config,
+		namespace: le.Namespace,
+		baseName:  baseName,
+		identity:  identity + "_" + string(uuid.NewUUID()),
+		timings:   timings,
+		logger:    logging.NewLogger("zenlead-shards").WithField("election", le.Namespace+"/"+baseName),
+		owned:     make(map[int]bool),
+		running:   make(map[int]*shardRun),
+	}, nil
+}
+
+// ShardLeaseName returns the Lease name of shard i for the base Lease name.
+func ShardLeaseName(baseName string, shard int) string {
+	return fmt.Sprintf("%s-shard-%d", baseName, shard)
+}
+
+// memberLeaseName returns the name of the member Lease for identity.
+// Identities may contain characters not allowed in names, so they are hashed.
+func memberLeaseName(baseName, identity string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(identity))
+	return fmt.Sprintf("%s-member-%08x", baseName, h.Sum32())
+}
+
+// Identity returns this replica's identity as recorded in Lease holders.
+func (s *ShardedElector) Identity() string {
+	return s.identity
+}
+
+// OnShardAcquired registers fn to be called when this replica starts leading a
+// shard. Events for the shard's objects were filtered out while another
+// replica owned it, so use this hook to requeue them (e.g., via a
+// source.Channel) if they must be reconciled promptly.
+func (s *ShardedElector) OnShardAcquired(fn func(shard int)) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.acquiredHooks = append(s.acquiredHooks, fn)
+}
+
+// OnShardReleased registers fn to be called when this replica stops leading a shard.
+func (s *ShardedElector) OnShardReleased(fn func(shard int)) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+	s.releasedHooks = append(s.releasedHooks, fn)
+}
+
+// ShardFor returns the shard of key, using jump consistent hashing: for a
+// given shard count every replica agrees, and growing the count from N to N+1
+// moves only 1/(N+1) of the keys.
+func (s *ShardedElector) ShardFor(key string) int {
+	return ShardFor(key, s.config.Shards)
+}
+
+// ShardFor returns the shard in [0, shards) that key is assigned to.
+func ShardFor(key string, shards int) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return jumpHash(h.Sum64(), shards)
+}
+
+// jumpHash is Lamping and Veach's jump consistent hash.
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// IsShardOwned reports whether this replica currently leads shard.
+func (s *ShardedElector) IsShardOwned(shard int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.owned[shard]
+}
+
+// OwnedShards returns the shards this replica currently leads, in order.
+func (s *ShardedElector) OwnedShards() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	shards := make([]int, 0, len(s.owned))
+	for shard, owned := range s.owned {
+		if owned {
+			shards = append(shards, shard)
+		}
+	}
+	sort.Ints(shards)
+	return shards
+}
+
+// OwnsKey reports whether this replica currently leads the shard of key.
+func (s *ShardedElector) OwnsKey(key string) bool {
+	return s.IsShardOwned(s.ShardFor(key))
+}
+
+// OwnsObject reports whether this replica currently leads the shard of obj.
+func (s *ShardedElector) OwnsObject(obj client.Object) bool {
+	return s.OwnsKey(s.config.KeyFunc(obj))
+}
+
+// Predicate returns a controller-runtime predicate that admits events only for
+// objects in shards this replica leads. Ownership can change between an event
+// and its reconcile, so reconcilers should re-check OwnsKey before writing.
+func (s *ShardedElector) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(s.OwnsObject)
+}
+
+// Run maintains this replica's member Lease and campaigns for its assigned
+// shards until ctx is canceled. On return all shards have been released
+// (their Leases are handed back) and the member Lease is deleted.
+func (s *ShardedElector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.timings.RetryPeriod)
+	defer ticker.Stop()
+
+	for {
+		if err := s.rebalance(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error(err, "Failed to rebalance shards",
+				logging.Operation("shard_rebalance"),
+				logging.ErrorCode("SHARD_REBALANCE_ERROR"))
+		}
+
+		select {
+		case <-ctx.Done():
+			s.stopAll()
+			s.deleteMember()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// rebalance renews the member Lease, recomputes the assignment and starts or
+// stops shard electors to match it.
+func (s *ShardedElector) rebalance(ctx context.Context) error {
+	if err := s.heartbeat(ctx); err != nil {
+		return err
+	}
+	members, err := s.liveMembers(ctx)
+	if err != nil {
+		return err
+	}
+
+	assignment := assignShards(s.config.Shards, members)
+	var mine []int
+	for shard := 0; shard < s.config.Shards; shard++ {
+		if assignment[shard] == s.identity {
+			mine = append(mine, shard)
+		}
+	}
+
+	s.mu.Lock()
+	changed := fmt.Sprint(mine) != fmt.Sprint(s.assigned)
+	s.assigned = mine
+	s.mu.Unlock()
+	if changed {
+		s.logger.Info("Shard assignment changed",
+			logging.Operation("shard_rebalance"),
+			logging.Int("members", len(members)),
+			logging.String("assigned", fmt.Sprint(mine)))
+	}
+
+	desired := make(map[int]bool, len(mine))
+	for _, shard := range mine {
+		desired[shard] = true
+	}
+	for shard := 0; shard < s.config.Shards; shard++ {
+		if desired[shard] {
+			s.start(ctx, shard)
+		} else {
+			s.stop(shard)
+		}
+	}
+	return nil
+}
+
+// heartbeat creates or renews this replica's member Lease.
+func (s *ShardedElector) heartbeat(ctx context.Context) error {
+	leases := s.client.CoordinationV1().Leases(s.namespace)
+	name := memberLeaseName(s.baseName, s.identity)
+	now := metav1.NowMicro()
+	durationSeconds := int32(s.timings.LeaseDuration / time.Second)
+	if durationSeconds < 1 {
+		durationSeconds = 1
+	}
+
+	lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: s.namespace,
+				Labels:    map[string]string{LabelShardElection: s.baseName, LabelShardRole: "member"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &s.identity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get member lease: %w", err)
+	}
+
+	lease.Spec.HolderIdentity = &s.identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &now
+	if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to renew member lease: %w", err)
+	}
+	return nil
+}
+
+// liveMembers returns the identities of members whose Lease has not expired,
+// always including this replica.
+func (s *ShardedElector) liveMembers(ctx context.Context) ([]string, error) {
+	selector := labels.SelectorFromSet(labels.Set{LabelShardElection: s.baseName, LabelShardRole: "member"})
+	list, err := s.client.CoordinationV1().Leases(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list member leases: %w", err)
+	}
+
+	now := time.Now()
+	seen := map[string]bool{s.identity: true}
+	members := []string{s.identity}
+	listed := make(map[string]bool, len(list.Items))
+	for i := range list.Items {
+		lease := &list.Items[i]
+		listed[lease.Namespace+"/"+lease.Name] = true
+		holder, _ := s.members.holder(lease, now)
+		if holder != "" && !seen[holder] {
+			seen[holder] = true
+			members = append(members, holder)
+		}
+	}
+	s.members.retain(listed)
+	sort.Strings(members)
+	return members, nil
+}
+
+// deleteMember removes this replica's member Lease so others rebalance
+// immediately instead of waiting for it to expire.
+func (s *ShardedElector) deleteMember() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timings.RenewDeadline)
+	defer cancel()
+	name := memberLeaseName(s.baseName, s.identity)
+	err := s.client.CoordinationV1().Leases(s.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		s.logger.Error(err, "Failed to delete member lease",
+			logging.Operation("shard_leave"),
+			logging.Name(name),
+			logging.ErrorCode("LEASE_DELETE_ERROR"))
+	}
+}
+
+// start runs a LeaderElector for shard unless one is already running.
+func (s *ShardedElector) start(ctx context.Context, shard int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if run, ok := s.running[shard]; ok {
+		select {
+		case <-run.done:
+			// The elector lost the shard and exited; campaign again
+		default:
+			return
+		}
+	}
+
+	leaseName := ShardLeaseName(s.baseName, shard)
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Name: leaseName, Namespace: s.namespace},
+			Client:     s.client.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: s.identity},
+			Labels:     map[string]string{LabelShardElection: s.baseName, LabelShardRole: "shard"},
+		},
+		LeaseDuration:   s.timings.LeaseDuration,
+		RenewDeadline:   s.timings.RenewDeadline,
+		RetryPeriod:     s.timings.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            leaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leadCtx context.Context) { s.acquired(leadCtx, shard) },
+			OnStoppedLeading: func() { s.released(shard) },
+		},
+	})
+	if err != nil {
+		s.logger.Error(err, "Failed to create shard elector",
+			logging.Operation("shard_campaign"),
+			logging.Name(leaseName),
+			logging.ErrorCode("SHARD_ELECTOR_ERROR"))
+		return
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	run := &shardRun{cancel: cancel, done: make(chan struct{})}
+	s.running[shard] = run
+	go func() {
+		defer close(run.done)
+		elector.Run(runCtx)
+	}()
+}
+
+// stop releases shard if its elector is running.
+func (s *ShardedElector) stop(shard int) {
+	s.mu.Lock()
+	run, ok := s.running[shard]
+	delete(s.running, shard)
+	s.mu.Unlock()
+
+	if ok {
+		run.cancel()
+		<-run.done
+	}
+}
+
+// stopAll releases every shard.
+func (s *ShardedElector) stopAll() {
+	for shard := 0; shard < s.config.Shards; shard++ {
+		s.stop(shard)
+	}
+}
+
+// acquired marks shard as owned. leadCtx is already canceled if leadership
+// ended before this asynchronous callback ran.
+func (s *ShardedElector) acquired(leadCtx context.Context, shard int) {
+	s.mu.Lock()
+	if leadCtx.Err() != nil || s.owned[shard] {
+		s.mu.Unlock()
+		return
+	}
+	s.owned[shard] = true
+	s.mu.Unlock()
+
+	leaseName := ShardLeaseName(s.baseName, shard)
+	s.logger.Info("Acquired shard",
+		logging.Operation("shard_campaign"),
+		logging.Name(leaseName),
+		logging.Int("shard", shard))
+	if s.config.Metrics != nil {
+		s.config.Metrics.ObserveTransition(leaseName, TransitionStartedLeading)
+		s.config.Metrics.SetLeader(leaseName, true)
+	}
+
+	s.hooksMu.Lock()
+	hooks := append([]func(int){}, s.acquiredHooks...)
+	s.hooksMu.Unlock()
+	for _, fn := range hooks {
+		fn(shard)
+	}
+}
+
+// released marks shard as not owned. LeaderElector calls it whenever it
+// exits, including when it never led.
+func (s *ShardedElector) released(shard int) {
+	s.mu.Lock()
+	if !s.owned[shard] {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.owned, shard)
+	s.mu.Unlock()
+
+	leaseName := ShardLeaseName(s.baseName, shard)
+	s.logger.Info("Released shard",
+		logging.Operation("shard_campaign"),
+		logging.Name(leaseName),
+		logging.Int("shard", shard))
+	if s.config.Metrics != nil {
+		s.config.Metrics.ObserveTransition(leaseName, TransitionStoppedLeading)
+		s.config.Metrics.SetLeader(leaseName, false)
+	}
+
+	s.hooksMu.Lock()
+	hooks := append([]func(int){}, s.releasedHooks...)
+	s.hooksMu.Unlock()
+	for _, fn := range hooks {
+		fn(shard)
+	}
+}
+
+// assignShards assigns each shard to a member. Every member computes the same
+// result from the same member list. Members are ranked per shard by
+// rendezvous hash, and each shard goes to its highest-ranked member that has
+// fewer than ceil(shards/members) shards, so the load is balanced and a join
+// or leave moves few shards.
+func assignShards(shards int, members []string) map[int]string {
+	assignment := make(map[int]string, shards)
+	if len(members) == 0 {
+		return assignment
+	}
+
+	capacity := (shards + len(members) - 1) / len(members)
+	load := make(map[string]int, len(members))
+	ranked := make([]string, len(members))
+	for shard := 0; shard < shards; shard++ {
+		copy(ranked, members)
+		sort.Slice(ranked, func(i, j int) bool {
+			si, sj := rendezvousScore(ranked[i], shard), rendezvousScore(ranked[j], shard)
+			if si != sj {
+				return si > sj
+			}
+			return ranked[i] < ranked[j]
+		})
+		for _, member := range ranked {
+			if load[member] < capacity {
+				assignment[shard] = member
+				load[member]++
+				break
+			}
+		}
+	}
+	return assignment
+}
+
+// rendezvousScore is the rendezvous (highest random weight) hash of member for shard.
+func rendezvousScore(member string, shard int) uint64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%s/%d", member, shard)
+	return h.Sum64()
+}
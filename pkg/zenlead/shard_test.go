@@ -0,0 +1,229 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestShardFor(t *testing.T) {
+	counts := make([]int, 8)
+	moved := 0
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("default/object-%d", i)
+		shard := ShardFor(key, 8)
+		if shard < 0 || shard >= 8 {
+			t.Fatalf("ShardFor(%q, 8) = %d, out of range", key, shard)
+		}
+		if ShardFor(key, 8) != shard {
+			t.Fatalf("ShardFor(%q, 8) is not deterministic", key)
+		}
+		counts[shard]++
+		if ShardFor(key, 9) != shard {
+			moved++
+		}
+	}
+
+	for shard, n := range counts {
+		if n < 1000 || n > 1500 {
+			t.Errorf("shard %d has %d of 10000 keys, want about 1250", shard, n)
+		}
+	}
+	// Consistent hashing: adding a ninth shard moves about 1/9 of the keys
+	if moved > 1500 {
+		t.Errorf("growing to 9 shards moved %d of 10000 keys, want about 1111", moved)
+	}
+}
+
+func TestAssignShards(t *testing.T) {
+	members := []string{"pod-a", "pod-b", "pod-c"}
+	assignment := assignShards(8, members)
+
+	load := map[string]int{}
+	for shard := 0; shard < 8; shard++ {
+		member, ok := assignment[shard]
+		if !ok {
+			t.Fatalf("shard %d unassigned", shard)
+		}
+		load[member]++
+	}
+	for _, member := range members {
+		if load[member] < 2 || load[member] > 3 {
+			t.Errorf("%s has %d shards, want 2 or 3", member, load[member])
+		}
+	}
+
+	if !reflect.DeepEqual(assignShards(8, members), assignment) {
+		t.Error("assignShards() is not deterministic")
+	}
+
+	// Every shard goes to the only member
+	for shard, member := range assignShards(4, []string{"pod-a"}) {
+		if member != "pod-a" {
+			t.Errorf("shard %d assigned to %q with a single member", shard, member)
+		}
+	}
+	if len(assignShards(4, nil)) != 0 {
+		t.Error("assignShards() with no members assigned shards")
+	}
+}
+
+// fastShardConfig returns a ShardConfig with the shortest allowed timings.
+func fastShardConfig(identity string) ShardConfig {
+	leaseDuration := MinLeaseDuration
+	renewDeadline := 600 * time.Millisecond
+	retryPeriod := MinRetryPeriod
+	return ShardConfig{
+		LeaderElection: &LeaderElectionConfig{
+			Mode:          BuiltIn,
+			ElectionID:    "watcher-leader-election",
+			Namespace:     "test-namespace",
+			LeaseDuration: &leaseDuration,
+			RenewDeadline: &renewDeadline,
+			RetryPeriod:   &retryPeriod,
+		},
+		Shards:   4,
+		Identity: identity,
+	}
+}
+
+// runShardedElector runs s until the returned stop function is called.
+func runShardedElector(s *ShardedElector) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = s.Run(ctx)
+	}()
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// waitFor polls cond until it holds or the timeout expires.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestShardedElector_Rebalance(t *testing.T) {
+	client := fake.NewClientset()
+	a, err := NewShardedElector(client, fastShardConfig("pod-a"))
+	if err != nil {
+		t.Fatalf("NewShardedElector() unexpected error: %v", err)
+	}
+	b, err := NewShardedElector(client, fastShardConfig("pod-b"))
+	if err != nil {
+		t.Fatalf("NewShardedElector() unexpected error: %v", err)
+	}
+
+	stopA := runShardedElector(a)
+	defer stopA()
+	waitFor(t, "pod-a to own all shards", func() bool {
+		return reflect.DeepEqual(a.OwnedShards(), []int{0, 1, 2, 3})
+	})
+
+	// pod-b joins: each replica ends up with two shards and no shard is shared
+	stopB := runShardedElector(b)
+	waitFor(t, "shards to be split between pod-a and pod-b", func() bool {
+		return len(a.OwnedShards()) == 2 && len(b.OwnedShards()) == 2
+	})
+	for shard := 0; shard < 4; shard++ {
+		if a.IsShardOwned(shard) == b.IsShardOwned(shard) {
+			t.Errorf("shard %d: pod-a owns=%v, pod-b owns=%v; want exactly one owner", shard, a.IsShardOwned(shard), b.IsShardOwned(shard))
+		}
+	}
+
+	// pod-b leaves: pod-a takes its shards back
+	stopB()
+	if owned := b.OwnedShards(); len(owned) != 0 {
+		t.Errorf("pod-b still owns %v after stopping", owned)
+	}
+	waitFor(t, "pod-a to own all shards again", func() bool {
+		return reflect.DeepEqual(a.OwnedShards(), []int{0, 1, 2, 3})
+	})
+}
+
+func TestShardedElector_Predicate(t *testing.T) {
+	s, err := NewShardedElector(fake.NewClientset(), fastShardConfig("pod-a"))
+	if err != nil {
+		t.Fatalf("NewShardedElector() unexpected error: %v", err)
+	}
+	var acquired []int
+	s.OnShardAcquired(func(shard int) { acquired = append(acquired, shard) })
+
+	mine := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "source-1"}}
+	shard := s.ShardFor("default/source-1")
+	pred := s.Predicate()
+
+	if pred.Create(event.CreateEvent{Object: mine}) {
+		t.Error("Predicate() admitted an object before its shard was acquired")
+	}
+
+	leadCtx := context.Background()
+	s.acquired(leadCtx, shard)
+	if !pred.Create(event.CreateEvent{Object: mine}) || !s.OwnsKey("default/source-1") {
+		t.Error("Predicate() rejected an object in an owned shard")
+	}
+	if !reflect.DeepEqual(acquired, []int{shard}) {
+		t.Errorf("OnShardAcquired calls = %v, want [%d]", acquired, shard)
+	}
+
+	s.released(shard)
+	if pred.Update(event.UpdateEvent{ObjectOld: mine, ObjectNew: mine}) {
+		t.Error("Predicate() admitted an object after its shard was released")
+	}
+
+	// A late OnStartedLeading for a term that already ended is ignored
+	ended, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.acquired(ended, shard)
+	if s.IsShardOwned(shard) {
+		t.Error("acquired() with a canceled leadership context marked the shard owned")
+	}
+}
+
+func TestNewShardedElector_Errors(t *testing.T) {
+	client := fake.NewClientset()
+	if _, err := NewShardedElector(client, ShardConfig{LeaderElection: builtInConfig()}); err == nil {
+		t.Error("NewShardedElector() without Shards expected error")
+	}
+	if _, err := NewShardedElector(client, ShardConfig{LeaderElection: &LeaderElectionConfig{Mode: Disabled}, Shards: 2}); err == nil {
+		t.Error("NewShardedElector() in Disabled mode expected error")
+	}
+	if got := ShardLeaseName("watcher-leader-election", 3); got != "watcher-leader-election-shard-3" {
+		t.Errorf("ShardLeaseName() = %q", got)
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimingProfile names a coherent set of leader election timings.
+type TimingProfile string
+
+const (
+	// ProfileFastFailover fails over within a few seconds of the leader dying,
+	// at the cost of a Lease renewal every second.
+	ProfileFastFailover TimingProfile = "fast-failover"
+
+	// ProfileDefault matches controller-runtime's defaults (15s/10s/2s).
+	ProfileDefault TimingProfile = "default"
+
+	// ProfileAPIFriendly renews every 10s, for large fleets or busy API
+	// servers where a minute of failover is acceptable.
+	ProfileAPIFriendly TimingProfile = "api-friendly"
+)
+
+// JitterFactor is the jitter client-go applies to RetryPeriod. RenewDeadline
+// must exceed RetryPeriod*JitterFactor or client-go rejects the config.
+const JitterFactor = 1.2
+
+// Minimum leader election timings. They reject unit mistakes such as
+// LeaseDuration: 15 (15ns instead of 15*time.Second); LeaseDuration is also
+// stored on the Lease in whole seconds.
+const (
+	MinLeaseDuration = time.Second
+	MinRenewDeadline = 500 * time.Millisecond
+	MinRetryPeriod   = 100 * time.Millisecond
+)
+
+// ErrInvalidTiming is wrapped by errors for incoherent leader election timings.
+var ErrInvalidTiming = errors.New("invalid leader election timing")
+
+// Timings are resolved leader election timings.
+type Timings struct {
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+var profiles = map[TimingProfile]Timings{
+	ProfileFastFailover: {LeaseDuration: 6 * time.Second, RenewDeadline: 4 * time.Second, RetryPeriod: time.Second},
+	ProfileDefault:      {LeaseDuration: 15 * time.Second, RenewDeadline: 10 * time.Second, RetryPeriod: 2 * time.Second},
+	ProfileAPIFriendly:  {LeaseDuration: 60 * time.Second, RenewDeadline: 40 * time.Second, RetryPeriod: 10 * time.Second},
+}
+
+// ProfileTimings returns the timings of a named profile.
+func ProfileTimings(profile TimingProfile) (Timings, error) {
+	t, ok := profiles[profile]
+	if !ok {
+		return Timings{}, fmt.Errorf("Profile: unknown timing profile %q (must be fast-failover, default, or api-friendly)", profile)
+	}
+	return t, nil
+}
+
+// EffectiveTimings returns the timings leader election will run with: the
+// explicit LeaseDuration/RenewDeadline/RetryPeriod, falling back to Profile,
+// then to ProfileDefault (controller-runtime's defaults).
+func (le *LeaderElectionConfig) EffectiveTimings() (Timings, error) {
+	profile := le.Profile
+	if profile == "" {
+		profile = ProfileDefault
+	}
+	t, err := ProfileTimings(profile)
+	if err != nil {
+		return Timings{}, err
+	}
+	if le.LeaseDuration != nil {
+		t.LeaseDuration = *le.LeaseDuration
+	}
+	if le.RenewDeadline != nil {
+		t.RenewDeadline = *le.RenewDeadline
+	}
+	if le.RetryPeriod != nil {
+		t.RetryPeriod = *le.RetryPeriod
+	}
+	return t, nil
+}
+
+// ValidateTimings checks the effective timings as ApplyConfig does. Wrappers
+// that validate the rest of a config themselves can use it.
+func (le *LeaderElectionConfig) ValidateTimings() error {
+	return validateTimings(le)
+}
+
+// validateTimings checks the effective timings: each at least its minimum and
+// LeaseDuration > RenewDeadline > RetryPeriod*JitterFactor.
+func validateTimings(le *LeaderElectionConfig) error {
+	t, err := le.EffectiveTimings()
+	if err != nil {
+		return err
+	}
+	leaseDuration := describeTiming(t.LeaseDuration, le.LeaseDuration)
+	renewDeadline := describeTiming(t.RenewDeadline, le.RenewDeadline)
+	retryPeriod := describeTiming(t.RetryPeriod, le.RetryPeriod)
+
+	switch {
+	case t.LeaseDuration < MinLeaseDuration:
+		return fmt.Errorf("%w: LeaseDuration %s is below the minimum %s", ErrInvalidTiming, leaseDuration, MinLeaseDuration)
+	case t.RenewDeadline < MinRenewDeadline:
+		return fmt.Errorf("%w: RenewDeadline %s is below the minimum %s", ErrInvalidTiming, renewDeadline, MinRenewDeadline)
+	case t.RetryPeriod < MinRetryPeriod:
+		return fmt.Errorf("%w: RetryPeriod %s is below the minimum %s", ErrInvalidTiming, retryPeriod, MinRetryPeriod)
+	case t.LeaseDuration <= t.RenewDeadline:
+		return fmt.Errorf("%w: LeaseDuration %s must be greater than RenewDeadline %s",
+			ErrInvalidTiming, leaseDuration, renewDeadline)
+	case float64(t.RenewDeadline) <= JitterFactor*float64(t.RetryPeriod):
+		return fmt.Errorf("%w: RenewDeadline %s must be greater than RetryPeriod %s × jitter %.1f",
+			ErrInvalidTiming, renewDeadline, retryPeriod, JitterFactor)
+	}
+	return nil
+}
+
+// describeTiming formats an effective timing, marking values not set explicitly.
+func describeTiming(effective time.Duration, explicit *time.Duration) string {
+	if explicit == nil {
+		return effective.String() + " (profile default)"
+	}
+	return effective.String()
+}
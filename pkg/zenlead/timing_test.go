@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Kube-ZEN Contributors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zenlead
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func durationRef(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestProfileTimings(t *testing.T) {
+	for _, profile := range []TimingProfile{ProfileFastFailover, ProfileDefault, ProfileAPIFriendly} {
+		t.Run(string(profile), func(t *testing.T) {
+			le := &LeaderElectionConfig{Mode: BuiltIn, ElectionID: "id", Namespace: "ns", Profile: profile}
+			if err := validateConfig(le); err != nil {
+				t.Errorf("profile %q is not valid: %v", profile, err)
+			}
+		})
+	}
+
+	if _, err := ProfileTimings("turbo"); err == nil || !strings.Contains(err.Error(), "Profile") {
+		t.Errorf("ProfileTimings(turbo) error = %v, want unknown profile", err)
+	}
+
+	def, _ := ProfileTimings(ProfileDefault)
+	if def != (Timings{LeaseDuration: 15 * time.Second, RenewDeadline: 10 * time.Second, RetryPeriod: 2 * time.Second}) {
+		t.Errorf("ProfileDefault = %+v, want controller-runtime defaults", def)
+	}
+}
+
+func TestEffectiveTimings(t *testing.T) {
+	le := &LeaderElectionConfig{Profile: ProfileAPIFriendly, RetryPeriod: durationRef(5 * time.Second)}
+	got, err := le.EffectiveTimings()
+	if err != nil {
+		t.Fatalf("EffectiveTimings() unexpected error: %v", err)
+	}
+	want := Timings{LeaseDuration: 60 * time.Second, RenewDeadline: 40 * time.Second, RetryPeriod: 5 * time.Second}
+	if got != want {
+		t.Errorf("EffectiveTimings() = %+v, want %+v", got, want)
+	}
+
+	got, _ = (&LeaderElectionConfig{}).EffectiveTimings()
+	if def, _ := ProfileTimings(ProfileDefault); got != def {
+		t.Errorf("EffectiveTimings() without profile = %+v, want %+v", got, def)
+	}
+}
+
+func TestValidateConfig_Timings(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  LeaderElectionConfig
+		wantErr string
+	}{
+		{
+			name:   "explicit valid timings",
+			config: LeaderElectionConfig{LeaseDuration: durationRef(20 * time.Second), RenewDeadline: durationRef(15 * time.Second), RetryPeriod: durationRef(5 * time.Second)},
+		},
+		{
+			name:    "lease duration in nanoseconds",
+			config:  LeaderElectionConfig{LeaseDuration: durationRef(15)},
+			wantErr: "LeaseDuration 15ns is below the minimum",
+		},
+		{
+			name:    "renew deadline below minimum",
+			config:  LeaderElectionConfig{RenewDeadline: durationRef(100 * time.Millisecond), RetryPeriod: durationRef(MinRetryPeriod)},
+			wantErr: "RenewDeadline 100ms is below the minimum",
+		},
+		{
+			name:    "retry period below minimum",
+			config:  LeaderElectionConfig{RetryPeriod: durationRef(time.Millisecond)},
+			wantErr: "RetryPeriod 1ms is below the minimum",
+		},
+		{
+			name:    "renew deadline equals lease duration",
+			config:  LeaderElectionConfig{LeaseDuration: durationRef(10 * time.Second), RenewDeadline: durationRef(10 * time.Second)},
+			wantErr: "LeaseDuration 10s must be greater than RenewDeadline 10s",
+		},
+		{
+			name:    "lease duration below default renew deadline",
+			config:  LeaderElectionConfig{LeaseDuration: durationRef(8 * time.Second)},
+			wantErr: "RenewDeadline 10s (profile default)",
+		},
+		{
+			name:    "renew deadline within retry jitter",
+			config:  LeaderElectionConfig{RenewDeadline: durationRef(5 * time.Second), RetryPeriod: durationRef(5 * time.Second)},
+			wantErr: "RenewDeadline 5s must be greater than RetryPeriod 5s × jitter 1.2",
+		},
+		{
+			name:    "renew deadline exactly retry jitter",
+			config:  LeaderElectionConfig{RenewDeadline: durationRef(12 * time.Second), RetryPeriod: durationRef(10 * time.Second)},
+			wantErr: "RenewDeadline 12s must be greater than RetryPeriod 10s",
+		},
+		{
+			name:    "profile override breaks ordering",
+			config:  LeaderElectionConfig{Profile: ProfileFastFailover, RetryPeriod: durationRef(4 * time.Second)},
+			wantErr: "RetryPeriod 4s × jitter",
+		},
+		{
+			name:    "unknown profile",
+			config:  LeaderElectionConfig{Profile: "turbo"},
+			wantErr: `unknown timing profile "turbo"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Mode = BuiltIn
+			tt.config.ElectionID = "id"
+			tt.config.Namespace = "ns"
+			err := validateConfig(&tt.config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateConfig() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateConfig() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	err := validateConfig(&LeaderElectionConfig{Mode: BuiltIn, ElectionID: "id", Namespace: "ns", LeaseDuration: durationRef(time.Second)})
+	if !errors.Is(err, ErrInvalidTiming) {
+		t.Errorf("validateConfig() error = %v, want ErrInvalidTiming", err)
+	}
+	if err := validateConfig(&LeaderElectionConfig{Mode: Disabled, LeaseDuration: durationRef(15)}); err != nil {
+		t.Errorf("validateConfig() checked timings in Disabled mode: %v", err)
+	}
+}
+
+func TestPrepareManagerOptions_Profile(t *testing.T) {
+	opts, err := PrepareManagerOptions(&ctrl.Options{}, &LeaderElectionConfig{
+		Mode:          BuiltIn,
+		ElectionID:    "test",
+		Namespace:     "test",
+		Profile:       ProfileFastFailover,
+		LeaseDuration: durationRef(8 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("PrepareManagerOptions failed: %v", err)
+	}
+	if opts.LeaseDuration == nil || *opts.LeaseDuration != 8*time.Second {
+		t.Errorf("LeaseDuration = %v, want explicit 8s", opts.LeaseDuration)
+	}
+	if opts.RenewDeadline == nil || *opts.RenewDeadline != 4*time.Second {
+		t.Errorf("RenewDeadline = %v, want fast-failover 4s", opts.RenewDeadline)
+	}
+	if opts.RetryPeriod == nil || *opts.RetryPeriod != time.Second {
+		t.Errorf("RetryPeriod = %v, want fast-failover 1s", opts.RetryPeriod)
+	}
+}
+
+func TestPrepareManagerOptions_OverrideWithoutProfile(t *testing.T) {
+	opts, err := PrepareManagerOptions(&ctrl.Options{}, &LeaderElectionConfig{
+		Mode:          BuiltIn,
+		ElectionID:    "test",
+		Namespace:     "test",
+		LeaseDuration: durationRef(30 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("PrepareManagerOptions failed: %v", err)
+	}
+	// The unset timings resolve to ProfileDefault
+	if opts.LeaseDuration == nil || *opts.LeaseDuration != 30*time.Second {
+		t.Errorf("LeaseDuration = %v, want explicit 30s", opts.LeaseDuration)
+	}
+	if opts.RenewDeadline == nil || *opts.RenewDeadline != 10*time.Second {
+		t.Errorf("RenewDeadline = %v, want default 10s", opts.RenewDeadline)
+	}
+	if opts.RetryPeriod == nil || *opts.RetryPeriod != 2*time.Second {
+		t.Errorf("RetryPeriod = %v, want default 2s", opts.RetryPeriod)
+	}
+
+	defaults, err := PrepareManagerOptions(&ctrl.Options{}, &LeaderElectionConfig{Mode: BuiltIn, ElectionID: "test", Namespace: "test"})
+	if err != nil {
+		t.Fatalf("PrepareManagerOptions failed: %v", err)
+	}
+	if defaults.LeaseDuration != nil || defaults.RenewDeadline != nil || defaults.RetryPeriod != nil {
+		t.Error("expected controller-runtime defaults without a profile or overrides")
+	}
+}